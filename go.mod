@@ -1,7 +1,7 @@
-module github.com/yourusername/whatsapp-go
-
-go 1.21
-
-require (
-	github.com/joho/godotenv v1.5.1
-)
+module github.com/yourusername/whatsapp-go
+
+go 1.21
+
+require (
+	github.com/joho/godotenv v1.5.1
+)
@@ -1,406 +1,1215 @@
-// Package builders provides fluent builders for creating WhatsApp messages.
-package builders
-
-import (
-	"github.com/yourusername/whatsapp-go/pkg/models"
-)
-
-// ===============================
-// Text Message Builder
-// ===============================
-
-// TextMessageBuilder builds text messages.
-type TextMessageBuilder struct {
-	to         string
-	body       string
-	previewURL bool
-	replyTo    string
-}
-
-// NewTextMessage creates a new text message builder.
-func NewTextMessage(to string) *TextMessageBuilder {
-	return &TextMessageBuilder{to: to}
-}
-
-// Body sets the message body.
-func (b *TextMessageBuilder) Body(body string) *TextMessageBuilder {
-	b.body = body
-	return b
-}
-
-// PreviewURL enables URL preview.
-func (b *TextMessageBuilder) PreviewURL(enable bool) *TextMessageBuilder {
-	b.previewURL = enable
-	return b
-}
-
-// ReplyTo sets the message ID to reply to.
-func (b *TextMessageBuilder) ReplyTo(messageID string) *TextMessageBuilder {
-	b.replyTo = messageID
-	return b
-}
-
-// Build creates the message request.
-func (b *TextMessageBuilder) Build() *models.MessageRequest {
-	req := &models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               b.to,
-		Type:             models.MessageTypeText,
-		Text: &models.TextContent{
-			Body:       b.body,
-			PreviewURL: b.previewURL,
-		},
-	}
-
-	if b.replyTo != "" {
-		req.Context = &models.Context{MessageID: b.replyTo}
-	}
-
-	return req
-}
-
-// ===============================
-// Interactive Button Builder
-// ===============================
-
-// ButtonMessageBuilder builds interactive button messages.
-type ButtonMessageBuilder struct {
-	to      string
-	body    string
-	header  *models.InteractiveHeader
-	footer  string
-	buttons []models.InteractiveButton
-}
-
-// NewButtonMessage creates a new button message builder.
-func NewButtonMessage(to string) *ButtonMessageBuilder {
-	return &ButtonMessageBuilder{
-		to:      to,
-		buttons: make([]models.InteractiveButton, 0, 3),
-	}
-}
-
-// Body sets the message body.
-func (b *ButtonMessageBuilder) Body(body string) *ButtonMessageBuilder {
-	b.body = body
-	return b
-}
-
-// Header sets a text header.
-func (b *ButtonMessageBuilder) Header(text string) *ButtonMessageBuilder {
-	b.header = &models.InteractiveHeader{
-		Type: "text",
-		Text: text,
-	}
-	return b
-}
-
-// HeaderImage sets an image header.
-func (b *ButtonMessageBuilder) HeaderImage(mediaID string) *ButtonMessageBuilder {
-	b.header = &models.InteractiveHeader{
-		Type:  "image",
-		Image: &models.MediaContent{ID: mediaID},
-	}
-	return b
-}
-
-// Footer sets the message footer.
-func (b *ButtonMessageBuilder) Footer(footer string) *ButtonMessageBuilder {
-	b.footer = footer
-	return b
-}
-
-// AddButton adds a reply button (max 3).
-func (b *ButtonMessageBuilder) AddButton(id, title string) *ButtonMessageBuilder {
-	if len(b.buttons) < 3 {
-		b.buttons = append(b.buttons, models.InteractiveButton{
-			Type: "reply",
-			Reply: models.InteractiveReply{
-				ID:    id,
-				Title: title,
-			},
-		})
-	}
-	return b
-}
-
-// Build creates the message request.
-func (b *ButtonMessageBuilder) Build() *models.MessageRequest {
-	interactive := &models.InteractiveContent{
-		Type: models.InteractiveTypeButton,
-		Body: models.InteractiveBody{Text: b.body},
-		Action: models.InteractiveAction{
-			Buttons: b.buttons,
-		},
-	}
-
-	if b.header != nil {
-		interactive.Header = b.header
-	}
-	if b.footer != "" {
-		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
-	}
-
-	return &models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               b.to,
-		Type:             models.MessageTypeInteractive,
-		Interactive:      interactive,
-	}
-}
-
-// ===============================
-// Interactive List Builder
-// ===============================
-
-// ListMessageBuilder builds interactive list messages.
-type ListMessageBuilder struct {
-	to         string
-	body       string
-	header     *models.InteractiveHeader
-	footer     string
-	buttonText string
-	sections   []models.InteractiveSection
-}
-
-// NewListMessage creates a new list message builder.
-func NewListMessage(to string) *ListMessageBuilder {
-	return &ListMessageBuilder{
-		to:       to,
-		sections: make([]models.InteractiveSection, 0),
-	}
-}
-
-// Body sets the message body.
-func (b *ListMessageBuilder) Body(body string) *ListMessageBuilder {
-	b.body = body
-	return b
-}
-
-// Header sets a text header.
-func (b *ListMessageBuilder) Header(text string) *ListMessageBuilder {
-	b.header = &models.InteractiveHeader{
-		Type: "text",
-		Text: text,
-	}
-	return b
-}
-
-// Footer sets the message footer.
-func (b *ListMessageBuilder) Footer(footer string) *ListMessageBuilder {
-	b.footer = footer
-	return b
-}
-
-// ButtonText sets the list button text.
-func (b *ListMessageBuilder) ButtonText(text string) *ListMessageBuilder {
-	b.buttonText = text
-	return b
-}
-
-// AddSection adds a section with rows.
-func (b *ListMessageBuilder) AddSection(title string, rows ...models.InteractiveRow) *ListMessageBuilder {
-	b.sections = append(b.sections, models.InteractiveSection{
-		Title: title,
-		Rows:  rows,
-	})
-	return b
-}
-
-// Build creates the message request.
-func (b *ListMessageBuilder) Build() *models.MessageRequest {
-	interactive := &models.InteractiveContent{
-		Type: models.InteractiveTypeList,
-		Body: models.InteractiveBody{Text: b.body},
-		Action: models.InteractiveAction{
-			Button:   b.buttonText,
-			Sections: b.sections,
-		},
-	}
-
-	if b.header != nil {
-		interactive.Header = b.header
-	}
-	if b.footer != "" {
-		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
-	}
-
-	return &models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               b.to,
-		Type:             models.MessageTypeInteractive,
-		Interactive:      interactive,
-	}
-}
-
-// Row is a helper to create an interactive row.
-func Row(id, title, description string) models.InteractiveRow {
-	return models.InteractiveRow{
-		ID:          id,
-		Title:       title,
-		Description: description,
-	}
-}
-
-// ===============================
-// Template Message Builder
-// ===============================
-
-// TemplateMessageBuilder builds template messages.
-type TemplateMessageBuilder struct {
-	to           string
-	templateName string
-	languageCode string
-	components   []models.TemplateComponent
-}
-
-// NewTemplateMessage creates a new template message builder.
-func NewTemplateMessage(to, templateName, languageCode string) *TemplateMessageBuilder {
-	return &TemplateMessageBuilder{
-		to:           to,
-		templateName: templateName,
-		languageCode: languageCode,
-		components:   make([]models.TemplateComponent, 0),
-	}
-}
-
-// AddHeaderText adds a text header parameter.
-func (b *TemplateMessageBuilder) AddHeaderText(text string) *TemplateMessageBuilder {
-	b.components = append(b.components, models.TemplateComponent{
-		Type: models.TemplateComponentHeader,
-		Parameters: []models.TemplateParameter{
-			{Type: models.TemplateParamText, Text: text},
-		},
-	})
-	return b
-}
-
-// AddHeaderImage adds an image header parameter.
-func (b *TemplateMessageBuilder) AddHeaderImage(mediaID string) *TemplateMessageBuilder {
-	b.components = append(b.components, models.TemplateComponent{
-		Type: models.TemplateComponentHeader,
-		Parameters: []models.TemplateParameter{
-			{Type: models.TemplateParamImage, Image: &models.MediaContent{ID: mediaID}},
-		},
-	})
-	return b
-}
-
-// AddBodyParams adds body text parameters.
-func (b *TemplateMessageBuilder) AddBodyParams(params ...string) *TemplateMessageBuilder {
-	parameters := make([]models.TemplateParameter, len(params))
-	for i, p := range params {
-		parameters[i] = models.TemplateParameter{
-			Type: models.TemplateParamText,
-			Text: p,
-		}
-	}
-
-	b.components = append(b.components, models.TemplateComponent{
-		Type:       models.TemplateComponentBody,
-		Parameters: parameters,
-	})
-	return b
-}
-
-// AddButtonPayload adds a quick reply button payload.
-func (b *TemplateMessageBuilder) AddButtonPayload(index, payload string) *TemplateMessageBuilder {
-	b.components = append(b.components, models.TemplateComponent{
-		Type:    models.TemplateComponentButton,
-		SubType: "quick_reply",
-		Index:   index,
-		Parameters: []models.TemplateParameter{
-			{Type: models.TemplateParamPayload, Payload: payload},
-		},
-	})
-	return b
-}
-
-// Build creates the message request.
-func (b *TemplateMessageBuilder) Build() *models.MessageRequest {
-	return &models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               b.to,
-		Type:             models.MessageTypeTemplate,
-		Template: &models.TemplateContent{
-			Name:       b.templateName,
-			Language:   models.TemplateLanguage{Code: b.languageCode},
-			Components: b.components,
-		},
-	}
-}
-
-// ===============================
-// Contact Builder
-// ===============================
-
-// ContactBuilder builds contact content.
-type ContactBuilder struct {
-	contact models.ContactContent
-}
-
-// NewContact creates a new contact builder.
-func NewContact(formattedName string) *ContactBuilder {
-	return &ContactBuilder{
-		contact: models.ContactContent{
-			Name: models.ContactName{
-				FormattedName: formattedName,
-			},
-		},
-	}
-}
-
-// FirstName sets the first name.
-func (b *ContactBuilder) FirstName(name string) *ContactBuilder {
-	b.contact.Name.FirstName = name
-	return b
-}
-
-// LastName sets the last name.
-func (b *ContactBuilder) LastName(name string) *ContactBuilder {
-	b.contact.Name.LastName = name
-	return b
-}
-
-// AddPhone adds a phone number.
-func (b *ContactBuilder) AddPhone(phoneType, phone string) *ContactBuilder {
-	b.contact.Phones = append(b.contact.Phones, models.ContactPhone{
-		Type:  phoneType,
-		Phone: phone,
-	})
-	return b
-}
-
-// AddEmail adds an email address.
-func (b *ContactBuilder) AddEmail(emailType, email string) *ContactBuilder {
-	b.contact.Emails = append(b.contact.Emails, models.ContactEmail{
-		Type:  emailType,
-		Email: email,
-	})
-	return b
-}
-
-// Organization sets the organization.
-func (b *ContactBuilder) Organization(company, department, title string) *ContactBuilder {
-	b.contact.Org = &models.ContactOrg{
-		Company:    company,
-		Department: department,
-		Title:      title,
-	}
-	return b
-}
-
-// Birthday sets the birthday.
-func (b *ContactBuilder) Birthday(date string) *ContactBuilder {
-	b.contact.Birthday = date
-	return b
-}
-
-// Build returns the contact content.
-func (b *ContactBuilder) Build() models.ContactContent {
-	return b.contact
-}
+// Package builders provides fluent builders for creating WhatsApp messages.
+package builders
+
+import (
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/formatting"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+	"github.com/yourusername/whatsapp-go/pkg/phone"
+)
+
+// ===============================
+// Text Message Builder
+// ===============================
+
+// TextMessageBuilder builds text messages.
+type TextMessageBuilder struct {
+	to         string
+	body       string
+	previewURL bool
+	replyTo    string
+}
+
+// NewTextMessage creates a new text message builder.
+func NewTextMessage(to string) *TextMessageBuilder {
+	return &TextMessageBuilder{to: to}
+}
+
+// Body sets the message body.
+func (b *TextMessageBuilder) Body(body string) *TextMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Markdown sets the message body from Markdown, converted to WhatsApp
+// formatting with formatting.FromMarkdown.
+func (b *TextMessageBuilder) Markdown(md string) *TextMessageBuilder {
+	b.body = formatting.FromMarkdown(md)
+	return b
+}
+
+// PreviewURL enables URL preview.
+func (b *TextMessageBuilder) PreviewURL(enable bool) *TextMessageBuilder {
+	b.previewURL = enable
+	return b
+}
+
+// ReplyTo sets the message ID to reply to.
+func (b *TextMessageBuilder) ReplyTo(messageID string) *TextMessageBuilder {
+	b.replyTo = messageID
+	return b
+}
+
+// Build creates the message request.
+func (b *TextMessageBuilder) Build() *models.MessageRequest {
+	req := &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeText,
+		Text: &models.TextContent{
+			Body:       b.body,
+			PreviewURL: b.previewURL,
+		},
+	}
+
+	if b.replyTo != "" {
+		req.Context = &models.Context{MessageID: b.replyTo}
+	}
+
+	return req
+}
+
+// ===============================
+// Interactive Button Builder
+// ===============================
+
+// ButtonMessageBuilder builds interactive button messages.
+type ButtonMessageBuilder struct {
+	to      string
+	body    string
+	header  *models.InteractiveHeader
+	footer  string
+	buttons []models.InteractiveButton
+}
+
+// NewButtonMessage creates a new button message builder.
+func NewButtonMessage(to string) *ButtonMessageBuilder {
+	return &ButtonMessageBuilder{
+		to:      to,
+		buttons: make([]models.InteractiveButton, 0, 3),
+	}
+}
+
+// Body sets the message body.
+func (b *ButtonMessageBuilder) Body(body string) *ButtonMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Header sets a text header.
+func (b *ButtonMessageBuilder) Header(text string) *ButtonMessageBuilder {
+	b.header = &models.InteractiveHeader{
+		Type: "text",
+		Text: text,
+	}
+	return b
+}
+
+// HeaderImage sets an image header.
+func (b *ButtonMessageBuilder) HeaderImage(mediaID string) *ButtonMessageBuilder {
+	b.header = &models.InteractiveHeader{
+		Type:  "image",
+		Image: &models.MediaContent{ID: mediaID},
+	}
+	return b
+}
+
+// HeaderImageLink sets an image header from a public URL.
+func (b *ButtonMessageBuilder) HeaderImageLink(link string) *ButtonMessageBuilder {
+	b.header = mediaHeader("image", models.MediaContent{Link: link})
+	return b
+}
+
+// HeaderImageFile sets an image header from a local file, uploaded when
+// the message is sent.
+func (b *ButtonMessageBuilder) HeaderImageFile(path string) *ButtonMessageBuilder {
+	b.header = mediaHeader("image", models.MediaContent{Path: path})
+	return b
+}
+
+// HeaderVideo sets a video header.
+func (b *ButtonMessageBuilder) HeaderVideo(mediaID string) *ButtonMessageBuilder {
+	b.header = mediaHeader("video", models.MediaContent{ID: mediaID})
+	return b
+}
+
+// HeaderVideoLink sets a video header from a public URL.
+func (b *ButtonMessageBuilder) HeaderVideoLink(link string) *ButtonMessageBuilder {
+	b.header = mediaHeader("video", models.MediaContent{Link: link})
+	return b
+}
+
+// HeaderVideoFile sets a video header from a local file, uploaded when
+// the message is sent.
+func (b *ButtonMessageBuilder) HeaderVideoFile(path string) *ButtonMessageBuilder {
+	b.header = mediaHeader("video", models.MediaContent{Path: path})
+	return b
+}
+
+// HeaderDocument sets a document header.
+func (b *ButtonMessageBuilder) HeaderDocument(mediaID string) *ButtonMessageBuilder {
+	b.header = mediaHeader("document", models.MediaContent{ID: mediaID})
+	return b
+}
+
+// HeaderDocumentLink sets a document header from a public URL.
+func (b *ButtonMessageBuilder) HeaderDocumentLink(link string) *ButtonMessageBuilder {
+	b.header = mediaHeader("document", models.MediaContent{Link: link})
+	return b
+}
+
+// HeaderDocumentFile sets a document header from a local file, uploaded
+// when the message is sent.
+func (b *ButtonMessageBuilder) HeaderDocumentFile(path string) *ButtonMessageBuilder {
+	b.header = mediaHeader("document", models.MediaContent{Path: path})
+	return b
+}
+
+// Footer sets the message footer.
+func (b *ButtonMessageBuilder) Footer(footer string) *ButtonMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// AddButton adds a reply button (max 3).
+func (b *ButtonMessageBuilder) AddButton(id, title string) *ButtonMessageBuilder {
+	if len(b.buttons) < 3 {
+		b.buttons = append(b.buttons, models.InteractiveButton{
+			Type: "reply",
+			Reply: models.InteractiveReply{
+				ID:    id,
+				Title: title,
+			},
+		})
+	}
+	return b
+}
+
+// Build creates the message request.
+func (b *ButtonMessageBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeButton,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Buttons: b.buttons,
+		},
+	}
+
+	if b.header != nil {
+		interactive.Header = b.header
+	}
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ===============================
+// Interactive List Builder
+// ===============================
+
+// ListMessageBuilder builds interactive list messages.
+type ListMessageBuilder struct {
+	to         string
+	body       string
+	header     *models.InteractiveHeader
+	footer     string
+	buttonText string
+	sections   []models.InteractiveSection
+}
+
+// NewListMessage creates a new list message builder.
+func NewListMessage(to string) *ListMessageBuilder {
+	return &ListMessageBuilder{
+		to:       to,
+		sections: make([]models.InteractiveSection, 0),
+	}
+}
+
+// Body sets the message body.
+func (b *ListMessageBuilder) Body(body string) *ListMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Header sets a text header.
+func (b *ListMessageBuilder) Header(text string) *ListMessageBuilder {
+	b.header = &models.InteractiveHeader{
+		Type: "text",
+		Text: text,
+	}
+	return b
+}
+
+// Footer sets the message footer.
+func (b *ListMessageBuilder) Footer(footer string) *ListMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// ButtonText sets the list button text.
+func (b *ListMessageBuilder) ButtonText(text string) *ListMessageBuilder {
+	b.buttonText = text
+	return b
+}
+
+// AddSection adds a section with rows.
+func (b *ListMessageBuilder) AddSection(title string, rows ...models.InteractiveRow) *ListMessageBuilder {
+	b.sections = append(b.sections, models.InteractiveSection{
+		Title: title,
+		Rows:  rows,
+	})
+	return b
+}
+
+// Build creates the message request.
+func (b *ListMessageBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeList,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Button:   b.buttonText,
+			Sections: b.sections,
+		},
+	}
+
+	if b.header != nil {
+		interactive.Header = b.header
+	}
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// Row is a helper to create an interactive row.
+func Row(id, title, description string) models.InteractiveRow {
+	return models.InteractiveRow{
+		ID:          id,
+		Title:       title,
+		Description: description,
+	}
+}
+
+// ===============================
+// Flow Message Builder
+// ===============================
+
+// FlowMessageBuilder builds interactive flow messages.
+type FlowMessageBuilder struct {
+	to     string
+	body   string
+	header *models.InteractiveHeader
+	footer string
+	params models.ActionParameters
+}
+
+// NewFlowMessage creates a new flow message builder for a published flow.
+func NewFlowMessage(to, flowID, cta string) *FlowMessageBuilder {
+	return &FlowMessageBuilder{
+		to: to,
+		params: models.ActionParameters{
+			FlowMessageVersion: models.FlowMessageVersion,
+			FlowID:             flowID,
+			FlowCTA:            cta,
+		},
+	}
+}
+
+// Body sets the message body.
+func (b *FlowMessageBuilder) Body(body string) *FlowMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Header sets a text header.
+func (b *FlowMessageBuilder) Header(text string) *FlowMessageBuilder {
+	b.header = &models.InteractiveHeader{
+		Type: "text",
+		Text: text,
+	}
+	return b
+}
+
+// Footer sets the message footer.
+func (b *FlowMessageBuilder) Footer(footer string) *FlowMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// FlowName identifies the flow by name instead of ID.
+func (b *FlowMessageBuilder) FlowName(name string) *FlowMessageBuilder {
+	b.params.FlowID = ""
+	b.params.FlowName = name
+	return b
+}
+
+// Token sets the flow token returned with the flow response.
+func (b *FlowMessageBuilder) Token(token string) *FlowMessageBuilder {
+	b.params.FlowToken = token
+	return b
+}
+
+// Draft sends the draft version of the flow for testing.
+func (b *FlowMessageBuilder) Draft() *FlowMessageBuilder {
+	b.params.Mode = models.FlowModeDraft
+	return b
+}
+
+// Navigate opens the flow at screen with optional initial data.
+func (b *FlowMessageBuilder) Navigate(screen string, data map[string]interface{}) *FlowMessageBuilder {
+	b.params.FlowAction = models.FlowActionNavigate
+	b.params.FlowActionPayload = &models.FlowActionPayload{
+		Screen: screen,
+		Data:   data,
+	}
+	return b
+}
+
+// DataExchange makes the flow request its first screen from the data endpoint.
+func (b *FlowMessageBuilder) DataExchange() *FlowMessageBuilder {
+	b.params.FlowAction = models.FlowActionDataExchange
+	b.params.FlowActionPayload = nil
+	return b
+}
+
+// Build creates the message request.
+func (b *FlowMessageBuilder) Build() *models.MessageRequest {
+	params := b.params
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeFlow,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Name:       "flow",
+			Parameters: &params,
+		},
+	}
+
+	if b.header != nil {
+		interactive.Header = b.header
+	}
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ===============================
+// Commerce Message Builders
+// ===============================
+
+// ProductMessageBuilder builds single-product messages.
+type ProductMessageBuilder struct {
+	to                string
+	body              string
+	footer            string
+	catalogID         string
+	productRetailerID string
+}
+
+// NewProductMessage creates a new single-product message builder.
+func NewProductMessage(to, catalogID, productRetailerID string) *ProductMessageBuilder {
+	return &ProductMessageBuilder{
+		to:                to,
+		catalogID:         catalogID,
+		productRetailerID: productRetailerID,
+	}
+}
+
+// Body sets the optional message body.
+func (b *ProductMessageBuilder) Body(body string) *ProductMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Footer sets the message footer.
+func (b *ProductMessageBuilder) Footer(footer string) *ProductMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// Build creates the message request.
+func (b *ProductMessageBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeProduct,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			CatalogID:         b.catalogID,
+			ProductRetailerID: b.productRetailerID,
+		},
+	}
+
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ProductListMessageBuilder builds multi-product messages.
+type ProductListMessageBuilder struct {
+	to        string
+	header    string
+	body      string
+	footer    string
+	catalogID string
+	sections  []models.InteractiveSection
+}
+
+// NewProductListMessage creates a new multi-product message builder.
+func NewProductListMessage(to, catalogID string) *ProductListMessageBuilder {
+	return &ProductListMessageBuilder{
+		to:        to,
+		catalogID: catalogID,
+		sections:  make([]models.InteractiveSection, 0),
+	}
+}
+
+// Header sets the required text header.
+func (b *ProductListMessageBuilder) Header(text string) *ProductListMessageBuilder {
+	b.header = text
+	return b
+}
+
+// Body sets the message body.
+func (b *ProductListMessageBuilder) Body(body string) *ProductListMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Footer sets the message footer.
+func (b *ProductListMessageBuilder) Footer(footer string) *ProductListMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// AddSection adds a section of products (max 10 sections, 30 products in total).
+func (b *ProductListMessageBuilder) AddSection(title string, productRetailerIDs ...string) *ProductListMessageBuilder {
+	b.sections = append(b.sections, ProductSection(title, productRetailerIDs...))
+	return b
+}
+
+// Build creates the message request.
+func (b *ProductListMessageBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type:   models.InteractiveTypeProductList,
+		Header: &models.InteractiveHeader{Type: "text", Text: b.header},
+		Body:   models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			CatalogID: b.catalogID,
+			Sections:  b.sections,
+		},
+	}
+
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ProductSection is a helper to create a product list section.
+func ProductSection(title string, productRetailerIDs ...string) models.InteractiveSection {
+	items := make([]models.ProductItem, len(productRetailerIDs))
+	for i, id := range productRetailerIDs {
+		items[i] = models.ProductItem{ProductRetailerID: id}
+	}
+
+	return models.InteractiveSection{
+		Title:        title,
+		ProductItems: items,
+	}
+}
+
+// CatalogMessageBuilder builds catalog messages.
+type CatalogMessageBuilder struct {
+	to        string
+	body      string
+	footer    string
+	thumbnail string
+}
+
+// NewCatalogMessage creates a new catalog message builder.
+func NewCatalogMessage(to string) *CatalogMessageBuilder {
+	return &CatalogMessageBuilder{to: to}
+}
+
+// Body sets the message body.
+func (b *CatalogMessageBuilder) Body(body string) *CatalogMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Footer sets the message footer.
+func (b *CatalogMessageBuilder) Footer(footer string) *CatalogMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// Thumbnail sets the product shown as the catalog thumbnail.
+func (b *CatalogMessageBuilder) Thumbnail(productRetailerID string) *CatalogMessageBuilder {
+	b.thumbnail = productRetailerID
+	return b
+}
+
+// Build creates the message request.
+func (b *CatalogMessageBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeCatalog,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Name: "catalog_message",
+		},
+	}
+
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	if b.thumbnail != "" {
+		interactive.Action.Parameters = &models.ActionParameters{
+			ThumbnailProductRetailerID: b.thumbnail,
+		}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ===============================
+// Location and Address Request Builders
+// ===============================
+
+// LocationRequestBuilder builds location request messages.
+type LocationRequestBuilder struct {
+	to   string
+	body string
+}
+
+// NewLocationRequest creates a new location request message builder.
+func NewLocationRequest(to string) *LocationRequestBuilder {
+	return &LocationRequestBuilder{to: to}
+}
+
+// Body sets the message body.
+func (b *LocationRequestBuilder) Body(body string) *LocationRequestBuilder {
+	b.body = body
+	return b
+}
+
+// Build creates the message request.
+func (b *LocationRequestBuilder) Build() *models.MessageRequest {
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive: &models.InteractiveContent{
+			Type: models.InteractiveTypeLocationRequest,
+			Body: models.InteractiveBody{Text: b.body},
+			Action: models.InteractiveAction{
+				Name: "send_location",
+			},
+		},
+	}
+}
+
+// AddressMessageBuilder builds address request messages.
+type AddressMessageBuilder struct {
+	to     string
+	body   string
+	footer string
+	params models.ActionParameters
+}
+
+// NewAddressMessage creates a new address message builder for a country (IN, SG).
+func NewAddressMessage(to, country string) *AddressMessageBuilder {
+	return &AddressMessageBuilder{
+		to:     to,
+		params: models.ActionParameters{Country: country},
+	}
+}
+
+// Body sets the message body.
+func (b *AddressMessageBuilder) Body(body string) *AddressMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Footer sets the message footer.
+func (b *AddressMessageBuilder) Footer(footer string) *AddressMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// Prefill sets values shown in the address form.
+func (b *AddressMessageBuilder) Prefill(values models.AddressValues) *AddressMessageBuilder {
+	b.params.Values = &values
+	return b
+}
+
+// AddSavedAddress adds an address the user can pick.
+func (b *AddressMessageBuilder) AddSavedAddress(id string, values models.AddressValues) *AddressMessageBuilder {
+	b.params.SavedAddresses = append(b.params.SavedAddresses, models.SavedAddress{ID: id, Value: values})
+	return b
+}
+
+// ValidationError shows an error next to a field, e.g. when re-requesting
+// an address that failed validation.
+func (b *AddressMessageBuilder) ValidationError(field, message string) *AddressMessageBuilder {
+	if b.params.ValidationErrors == nil {
+		b.params.ValidationErrors = make(map[string]string)
+	}
+	b.params.ValidationErrors[field] = message
+	return b
+}
+
+// Build creates the message request.
+func (b *AddressMessageBuilder) Build() *models.MessageRequest {
+	params := b.params
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeAddress,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Name:       "address_message",
+			Parameters: &params,
+		},
+	}
+
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ===============================
+// Call Builders
+// ===============================
+
+// VoiceCallMessageBuilder builds voice call button messages.
+type VoiceCallMessageBuilder struct {
+	to     string
+	body   string
+	footer string
+	params models.ActionParameters
+}
+
+// NewVoiceCallMessage creates a new voice call button message builder.
+func NewVoiceCallMessage(to, displayText string) *VoiceCallMessageBuilder {
+	return &VoiceCallMessageBuilder{
+		to:     to,
+		params: models.ActionParameters{DisplayText: displayText},
+	}
+}
+
+// Body sets the message body.
+func (b *VoiceCallMessageBuilder) Body(body string) *VoiceCallMessageBuilder {
+	b.body = body
+	return b
+}
+
+// Footer sets the message footer.
+func (b *VoiceCallMessageBuilder) Footer(footer string) *VoiceCallMessageBuilder {
+	b.footer = footer
+	return b
+}
+
+// TTL sets how many minutes the call button stays active.
+func (b *VoiceCallMessageBuilder) TTL(minutes int) *VoiceCallMessageBuilder {
+	b.params.TTLMinutes = minutes
+	return b
+}
+
+// Payload sets an opaque payload returned in the call webhook.
+func (b *VoiceCallMessageBuilder) Payload(payload string) *VoiceCallMessageBuilder {
+	b.params.Payload = payload
+	return b
+}
+
+// Build creates the message request.
+func (b *VoiceCallMessageBuilder) Build() *models.MessageRequest {
+	params := b.params
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeVoiceCall,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Name:       "voice_call",
+			Parameters: &params,
+		},
+	}
+
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// CallPermissionRequestBuilder builds call permission request messages.
+type CallPermissionRequestBuilder struct {
+	to     string
+	body   string
+	header string
+	footer string
+}
+
+// NewCallPermissionRequest creates a new call permission request builder.
+func NewCallPermissionRequest(to string) *CallPermissionRequestBuilder {
+	return &CallPermissionRequestBuilder{to: to}
+}
+
+// Body sets the message body.
+func (b *CallPermissionRequestBuilder) Body(body string) *CallPermissionRequestBuilder {
+	b.body = body
+	return b
+}
+
+// Header sets a text header.
+func (b *CallPermissionRequestBuilder) Header(text string) *CallPermissionRequestBuilder {
+	b.header = text
+	return b
+}
+
+// Footer sets the message footer.
+func (b *CallPermissionRequestBuilder) Footer(footer string) *CallPermissionRequestBuilder {
+	b.footer = footer
+	return b
+}
+
+// Build creates the message request.
+func (b *CallPermissionRequestBuilder) Build() *models.MessageRequest {
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeCallPermission,
+		Body: models.InteractiveBody{Text: b.body},
+		Action: models.InteractiveAction{
+			Name: "call_permission_request",
+		},
+	}
+
+	if b.header != "" {
+		interactive.Header = &models.InteractiveHeader{Type: "text", Text: b.header}
+	}
+	if b.footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: b.footer}
+	}
+
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+}
+
+// ===============================
+// Template Message Builder
+// ===============================
+
+// TemplateMessageBuilder builds template messages.
+type TemplateMessageBuilder struct {
+	to           string
+	templateName string
+	languageCode string
+	components   []models.TemplateComponent
+}
+
+// NewTemplateMessage creates a new template message builder.
+func NewTemplateMessage(to, templateName, languageCode string) *TemplateMessageBuilder {
+	return &TemplateMessageBuilder{
+		to:           to,
+		templateName: templateName,
+		languageCode: languageCode,
+		components:   make([]models.TemplateComponent, 0),
+	}
+}
+
+// AddHeaderText adds a text header parameter.
+func (b *TemplateMessageBuilder) AddHeaderText(text string) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type: models.TemplateComponentHeader,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamText, Text: text},
+		},
+	})
+	return b
+}
+
+// AddHeaderImage adds an image header parameter.
+func (b *TemplateMessageBuilder) AddHeaderImage(mediaID string) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type: models.TemplateComponentHeader,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamImage, Image: &models.MediaContent{ID: mediaID}},
+		},
+	})
+	return b
+}
+
+// AddBodyParams adds body text parameters.
+func (b *TemplateMessageBuilder) AddBodyParams(params ...string) *TemplateMessageBuilder {
+	parameters := make([]models.TemplateParameter, len(params))
+	for i, p := range params {
+		parameters[i] = models.TemplateParameter{
+			Type: models.TemplateParamText,
+			Text: p,
+		}
+	}
+
+	b.components = append(b.components, models.TemplateComponent{
+		Type:       models.TemplateComponentBody,
+		Parameters: parameters,
+	})
+	return b
+}
+
+// AddButtonPayload adds a quick reply button payload.
+func (b *TemplateMessageBuilder) AddButtonPayload(index, payload string) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: "quick_reply",
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamPayload, Payload: payload},
+		},
+	})
+	return b
+}
+
+// AddURLButtonParam adds the dynamic suffix of a URL button.
+func (b *TemplateMessageBuilder) AddURLButtonParam(index, text string) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: models.TemplateButtonSubTypeURL,
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamText, Text: text},
+		},
+	})
+	return b
+}
+
+// AddOTPCode fills an authentication template: the code is set as the body
+// parameter and as the parameter of the OTP button at index 0.
+func (b *TemplateMessageBuilder) AddOTPCode(code string) *TemplateMessageBuilder {
+	return b.AddBodyParams(code).AddURLButtonParam("0", code)
+}
+
+// AddCouponCode adds the coupon code of a copy code button.
+func (b *TemplateMessageBuilder) AddCouponCode(index, code string) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: models.TemplateButtonSubTypeCopyCode,
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamCouponCode, CouponCode: code},
+		},
+	})
+	return b
+}
+
+// AddFlowButton adds the token and optional initial data of a flow button.
+func (b *TemplateMessageBuilder) AddFlowButton(index, flowToken string, data map[string]interface{}) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: models.TemplateButtonSubTypeFlow,
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{
+				Type:   models.TemplateParamAction,
+				Action: &models.TemplateActionParam{FlowToken: flowToken, FlowActionData: data},
+			},
+		},
+	})
+	return b
+}
+
+// AddLimitedTimeOffer sets the expiration of a limited-time offer.
+func (b *TemplateMessageBuilder) AddLimitedTimeOffer(expiresAt time.Time) *TemplateMessageBuilder {
+	b.components = append(b.components, models.TemplateComponent{
+		Type: models.TemplateComponentLimitedTimeOffer,
+		Parameters: []models.TemplateParameter{
+			{
+				Type:             models.TemplateParamLimitedTimeOffer,
+				LimitedTimeOffer: &models.LimitedTimeOfferParam{ExpirationTimeMs: expiresAt.UnixMilli()},
+			},
+		},
+	})
+	return b
+}
+
+// AddCarousel adds the cards of a carousel template (max 10). Cards beyond
+// the limit are kept so the request's Validate reports them.
+func (b *TemplateMessageBuilder) AddCarousel(cards ...*CarouselCardBuilder) *TemplateMessageBuilder {
+	built := make([]models.TemplateCard, len(cards))
+	for i, card := range cards {
+		built[i] = models.TemplateCard{
+			CardIndex:  i,
+			Components: card.components,
+		}
+	}
+
+	b.components = append(b.components, models.TemplateComponent{
+		Type:  models.TemplateComponentCarousel,
+		Cards: built,
+	})
+	return b
+}
+
+// Build creates the message request.
+func (b *TemplateMessageBuilder) Build() *models.MessageRequest {
+	return &models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               b.to,
+		Type:             models.MessageTypeTemplate,
+		Template: &models.TemplateContent{
+			Name:       b.templateName,
+			Language:   models.TemplateLanguage{Code: b.languageCode},
+			Components: b.components,
+		},
+	}
+}
+
+// ===============================
+// Carousel Card Builder
+// ===============================
+
+// CarouselCardBuilder builds the parameters of one carousel card.
+type CarouselCardBuilder struct {
+	components []models.TemplateComponent
+}
+
+// NewCarouselCard creates a new carousel card builder.
+func NewCarouselCard() *CarouselCardBuilder {
+	return &CarouselCardBuilder{
+		components: make([]models.TemplateComponent, 0),
+	}
+}
+
+// HeaderImage sets the card's image header.
+func (c *CarouselCardBuilder) HeaderImage(mediaID string) *CarouselCardBuilder {
+	c.components = append(c.components, models.TemplateComponent{
+		Type: models.TemplateComponentHeader,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamImage, Image: &models.MediaContent{ID: mediaID}},
+		},
+	})
+	return c
+}
+
+// HeaderVideo sets the card's video header.
+func (c *CarouselCardBuilder) HeaderVideo(mediaID string) *CarouselCardBuilder {
+	c.components = append(c.components, models.TemplateComponent{
+		Type: models.TemplateComponentHeader,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamVideo, Video: &models.MediaContent{ID: mediaID}},
+		},
+	})
+	return c
+}
+
+// BodyParams sets the card's body text parameters.
+func (c *CarouselCardBuilder) BodyParams(params ...string) *CarouselCardBuilder {
+	parameters := make([]models.TemplateParameter, len(params))
+	for i, p := range params {
+		parameters[i] = models.TemplateParameter{
+			Type: models.TemplateParamText,
+			Text: p,
+		}
+	}
+
+	c.components = append(c.components, models.TemplateComponent{
+		Type:       models.TemplateComponentBody,
+		Parameters: parameters,
+	})
+	return c
+}
+
+// ButtonPayload sets a quick reply button payload.
+func (c *CarouselCardBuilder) ButtonPayload(index, payload string) *CarouselCardBuilder {
+	c.components = append(c.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: models.TemplateButtonSubTypeQuickReply,
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamPayload, Payload: payload},
+		},
+	})
+	return c
+}
+
+// URLButtonParam sets the dynamic suffix of a URL button.
+func (c *CarouselCardBuilder) URLButtonParam(index, text string) *CarouselCardBuilder {
+	c.components = append(c.components, models.TemplateComponent{
+		Type:    models.TemplateComponentButton,
+		SubType: models.TemplateButtonSubTypeURL,
+		Index:   index,
+		Parameters: []models.TemplateParameter{
+			{Type: models.TemplateParamText, Text: text},
+		},
+	})
+	return c
+}
+
+// ===============================
+// Contact Builder
+// ===============================
+
+// ContactBuilder builds contact content.
+type ContactBuilder struct {
+	contact models.ContactContent
+}
+
+// NewContact creates a new contact builder.
+func NewContact(formattedName string) *ContactBuilder {
+	return &ContactBuilder{
+		contact: models.ContactContent{
+			Name: models.ContactName{
+				FormattedName: formattedName,
+			},
+		},
+	}
+}
+
+// FirstName sets the first name.
+func (b *ContactBuilder) FirstName(name string) *ContactBuilder {
+	b.contact.Name.FirstName = name
+	return b
+}
+
+// LastName sets the last name.
+func (b *ContactBuilder) LastName(name string) *ContactBuilder {
+	b.contact.Name.LastName = name
+	return b
+}
+
+// AddPhone adds a phone number.
+func (b *ContactBuilder) AddPhone(phoneType, phone string) *ContactBuilder {
+	b.contact.Phones = append(b.contact.Phones, models.ContactPhone{
+		Type:  phoneType,
+		Phone: phone,
+	})
+	return b
+}
+
+// AddEmail adds an email address.
+func (b *ContactBuilder) AddEmail(emailType, email string) *ContactBuilder {
+	b.contact.Emails = append(b.contact.Emails, models.ContactEmail{
+		Type:  emailType,
+		Email: email,
+	})
+	return b
+}
+
+// Organization sets the organization.
+func (b *ContactBuilder) Organization(company, department, title string) *ContactBuilder {
+	b.contact.Org = &models.ContactOrg{
+		Company:    company,
+		Department: department,
+		Title:      title,
+	}
+	return b
+}
+
+// Birthday sets the birthday.
+func (b *ContactBuilder) Birthday(date string) *ContactBuilder {
+	b.contact.Birthday = date
+	return b
+}
+
+// Build returns the contact content.
+func (b *ContactBuilder) Build() models.ContactContent {
+	return b.contact
+}
+
+// ===============================
+// Recipient Normalization
+// ===============================
+
+// NormalizeRecipient normalizes the recipient of a built message to
+// WhatsApp's format, using region (e.g. "US") for numbers without a
+// country code:
+//
+//	req, err := builders.NormalizeRecipient(builders.NewTextMessage("(415) 555-0123").Body("Hi").Build(), "US")
+func NormalizeRecipient(req *models.MessageRequest, region string) (*models.MessageRequest, error) {
+	to, err := phone.Normalize(req.To, region)
+	if err != nil {
+		return nil, errors.NewValidationError("to", err.Error())
+	}
+	req.To = to
+
+	return req, nil
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// mediaHeader creates an interactive header of the given media kind.
+func mediaHeader(kind string, media models.MediaContent) *models.InteractiveHeader {
+	header := &models.InteractiveHeader{Type: kind}
+	switch kind {
+	case "image":
+		header.Image = &media
+	case "video":
+		header.Video = &media
+	case "document":
+		header.Document = &media
+	}
+	return header
+}
@@ -1,249 +1,1084 @@
-// Package client provides business profile operations for the WhatsApp API.
-package client
-
-import (
-	"context"
-	"fmt"
-
-	"github.com/yourusername/whatsapp-go/pkg/models"
-)
-
-// ===============================
-// Business Profile
-// ===============================
-
-// GetBusinessProfile retrieves the business profile.
-func (c *Client) GetBusinessProfile(ctx context.Context, fields ...string) (*models.BusinessProfile, error) {
-	url := c.config.GetBusinessProfileURL()
-
-	// Add fields parameter if specified
-	if len(fields) > 0 {
-		url += "?fields="
-		for i, field := range fields {
-			if i > 0 {
-				url += ","
-			}
-			url += field
-		}
-	} else {
-		// Default fields
-		url += "?fields=about,address,description,email,profile_picture_url,websites,vertical"
-	}
-
-	var resp models.BusinessProfileResponse
-	if err := c.Get(ctx, url, &resp); err != nil {
-		return nil, err
-	}
-
-	if len(resp.Data) == 0 {
-		return nil, fmt.Errorf("no business profile data returned")
-	}
-
-	return &resp.Data[0], nil
-}
-
-// UpdateBusinessProfile updates the business profile.
-func (c *Client) UpdateBusinessProfile(ctx context.Context, profile *models.BusinessProfile) error {
-	if profile == nil {
-		return fmt.Errorf("profile is required")
-	}
-
-	profile.MessagingProduct = models.MessagingProduct
-
-	url := c.config.GetBusinessProfileURL()
-
-	var result struct {
-		Success bool `json:"success"`
-	}
-
-	if err := c.Post(ctx, url, profile, &result); err != nil {
-		return err
-	}
-
-	if !result.Success {
-		return fmt.Errorf("failed to update business profile")
-	}
-
-	return nil
-}
-
-// ===============================
-// Phone Numbers
-// ===============================
-
-// GetPhoneNumbers retrieves all phone numbers for the business account.
-func (c *Client) GetPhoneNumbers(ctx context.Context) (*models.PhoneNumbersResponse, error) {
-	if c.config.BusinessAccountID == "" {
-		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
-	}
-
-	url := fmt.Sprintf("%s/%s/phone_numbers", c.config.GetAPIURL(), c.config.BusinessAccountID)
-
-	var resp models.PhoneNumbersResponse
-	if err := c.Get(ctx, url, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
-// GetPhoneNumber retrieves information about a specific phone number.
-func (c *Client) GetPhoneNumber(ctx context.Context, phoneNumberID string) (*models.PhoneNumber, error) {
-	if phoneNumberID == "" {
-		phoneNumberID = c.config.PhoneNumberID
-	}
-
-	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), phoneNumberID)
-
-	var resp models.PhoneNumber
-	if err := c.Get(ctx, url, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
-// ===============================
-// Message Templates
-// ===============================
-
-// GetTemplates retrieves all message templates.
-func (c *Client) GetTemplates(ctx context.Context) (*models.TemplatesResponse, error) {
-	if c.config.BusinessAccountID == "" {
-		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
-	}
-
-	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
-
-	var resp models.TemplatesResponse
-	if err := c.Get(ctx, url, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
-// GetTemplate retrieves a specific message template by name.
-func (c *Client) GetTemplate(ctx context.Context, templateName string) (*models.Template, error) {
-	templates, err := c.GetTemplates(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, t := range templates.Data {
-		if t.Name == templateName {
-			return &t, nil
-		}
-	}
-
-	return nil, fmt.Errorf("template '%s' not found", templateName)
-}
-
-// CreateTemplateRequest contains the data for creating a new template.
-type CreateTemplateRequest struct {
-	Name       string                       `json:"name"`
-	Category   string                       `json:"category"` // MARKETING, UTILITY, AUTHENTICATION
-	Language   string                       `json:"language"`
-	Components []models.TemplateComponentDef `json:"components"`
-}
-
-// CreateTemplate creates a new message template.
-func (c *Client) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*models.Template, error) {
-	if c.config.BusinessAccountID == "" {
-		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
-	}
-
-	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
-
-	var resp models.Template
-	if err := c.Post(ctx, url, req, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
-// DeleteTemplate deletes a message template.
-func (c *Client) DeleteTemplate(ctx context.Context, templateName string) error {
-	if c.config.BusinessAccountID == "" {
-		return fmt.Errorf("BusinessAccountID is required for this operation")
-	}
-
-	url := fmt.Sprintf("%s/%s/message_templates?name=%s", c.config.GetAPIURL(), c.config.BusinessAccountID, templateName)
-
-	var result struct {
-		Success bool `json:"success"`
-	}
-
-	if err := c.Delete(ctx, url, &result); err != nil {
-		return err
-	}
-
-	if !result.Success {
-		return fmt.Errorf("failed to delete template")
-	}
-
-	return nil
-}
-
-// ===============================
-// Two-Step Verification
-// ===============================
-
-// SetTwoStepVerificationPin sets the two-step verification PIN.
-func (c *Client) SetTwoStepVerificationPin(ctx context.Context, pin string) error {
-	if len(pin) != 6 {
-		return fmt.Errorf("PIN must be exactly 6 digits")
-	}
-
-	url := c.config.GetAPIURL() + "/" + c.config.PhoneNumberID
-
-	body := map[string]string{"pin": pin}
-
-	var result struct {
-		Success bool `json:"success"`
-	}
-
-	if err := c.Post(ctx, url, body, &result); err != nil {
-		return err
-	}
-
-	if !result.Success {
-		return fmt.Errorf("failed to set two-step verification PIN")
-	}
-
-	return nil
-}
-
-// ===============================
-// Message Read Status
-// ===============================
-
-// MarkMessageAsRead marks a message as read.
-func (c *Client) MarkMessageAsRead(ctx context.Context, messageID string) error {
-	if messageID == "" {
-		return fmt.Errorf("messageID is required")
-	}
-
-	url := c.config.GetMessagesURL()
-
-	body := map[string]interface{}{
-		"messaging_product": models.MessagingProduct,
-		"status":            "read",
-		"message_id":        messageID,
-	}
-
-	var result struct {
-		Success bool `json:"success"`
-	}
-
-	if err := c.Post(ctx, url, body, &result); err != nil {
-		return err
-	}
-
-	if !result.Success {
-		return fmt.Errorf("failed to mark message as read")
-	}
-
-	return nil
-}
+// Package client provides business profile operations for the WhatsApp API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+	"github.com/yourusername/whatsapp-go/pkg/templates"
+)
+
+// ===============================
+// Business Profile
+// ===============================
+
+// GetBusinessProfile retrieves the business profile.
+func (c *Client) GetBusinessProfile(ctx context.Context, fields ...string) (*models.BusinessProfile, error) {
+	url := c.config.GetBusinessProfileURL()
+
+	// Add fields parameter if specified
+	if len(fields) > 0 {
+		url += "?fields="
+		for i, field := range fields {
+			if i > 0 {
+				url += ","
+			}
+			url += field
+		}
+	} else {
+		// Default fields
+		url += "?fields=about,address,description,email,profile_picture_url,websites,vertical"
+	}
+
+	var resp models.BusinessProfileResponse
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no business profile data returned")
+	}
+
+	return &resp.Data[0], nil
+}
+
+// UpdateBusinessProfile updates the business profile.
+func (c *Client) UpdateBusinessProfile(ctx context.Context, profile *models.BusinessProfile) error {
+	if profile == nil {
+		return fmt.Errorf("profile is required")
+	}
+
+	profile.MessagingProduct = models.MessagingProduct
+
+	url := c.config.GetBusinessProfileURL()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, profile, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to update business profile")
+	}
+
+	return nil
+}
+
+// ===============================
+// Phone Numbers
+// ===============================
+
+// GetPhoneNumbers retrieves the first page of phone numbers for the business account.
+// Use ListPhoneNumbers to iterate over every page.
+func (c *Client) GetPhoneNumbers(ctx context.Context) (*models.PhoneNumbersResponse, error) {
+	if c.config.BusinessAccountID == "" {
+		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+
+	url := fmt.Sprintf("%s/%s/phone_numbers", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	var resp models.PhoneNumbersResponse
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListPhoneNumbers returns an iterator over all phone numbers for the business account.
+func (c *Client) ListPhoneNumbers(ctx context.Context, opts *ListOptions) *Iterator[models.PhoneNumber] {
+	if c.config.BusinessAccountID == "" {
+		return errIterator[models.PhoneNumber](fmt.Errorf("BusinessAccountID is required for this operation"))
+	}
+
+	url := fmt.Sprintf("%s/%s/phone_numbers", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	query := neturl.Values{}
+	opts.apply(query)
+
+	return newIterator[models.PhoneNumber](ctx, c, url, query)
+}
+
+// GetPhoneNumber retrieves information about a specific phone number.
+func (c *Client) GetPhoneNumber(ctx context.Context, phoneNumberID string) (*models.PhoneNumber, error) {
+	if phoneNumberID == "" {
+		phoneNumberID = c.config.PhoneNumberID
+	}
+
+	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), phoneNumberID)
+
+	var resp models.PhoneNumber
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ===============================
+// Message Templates
+// ===============================
+
+// GetTemplates retrieves the first page of message templates.
+// Use ListTemplates to iterate over every page.
+func (c *Client) GetTemplates(ctx context.Context) (*models.TemplatesResponse, error) {
+	if c.config.BusinessAccountID == "" {
+		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+
+	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	var resp models.TemplatesResponse
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetTemplate retrieves a specific message template by name.
+// All pages are searched; if the template exists in several languages the
+// first match is returned. Use GetTemplateByNameAndLanguage to pick one.
+func (c *Client) GetTemplate(ctx context.Context, templateName string) (*models.Template, error) {
+	if templateName == "" {
+		return nil, errors.NewValidationError("templateName", "template name is required")
+	}
+
+	it := c.ListTemplates(ctx, &TemplateFilter{Name: templateName})
+	for it.Next() {
+		if t := it.Value(); t.Name == templateName {
+			return t, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("template '%s' not found", templateName)
+}
+
+// GetTemplateByNameAndLanguage retrieves the template with the given name and language.
+func (c *Client) GetTemplateByNameAndLanguage(ctx context.Context, templateName, language string) (*models.Template, error) {
+	if templateName == "" {
+		return nil, errors.NewValidationError("templateName", "template name is required")
+	}
+	if language == "" {
+		return nil, errors.NewValidationError("language", "language is required")
+	}
+
+	it := c.ListTemplates(ctx, &TemplateFilter{Name: templateName, Language: language})
+	for it.Next() {
+		if t := it.Value(); t.Name == templateName && t.Language == language {
+			return t, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("template '%s' (%s) not found", templateName, language)
+}
+
+// GetTemplateByID retrieves a message template by its ID.
+func (c *Client) GetTemplateByID(ctx context.Context, templateID string) (*models.Template, error) {
+	if templateID == "" {
+		return nil, errors.NewValidationError("templateID", "template ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), templateID)
+
+	var resp models.Template
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// TemplateFilter contains server-side filters for listing templates.
+// Empty fields are not sent.
+type TemplateFilter struct {
+	ListOptions
+
+	Name         string // Matches templates whose name contains this value
+	Status       string // APPROVED, PENDING, REJECTED, PAUSED, DISABLED
+	Category     string // MARKETING, UTILITY, AUTHENTICATION
+	Language     string // e.g., "en_US"
+	QualityScore string // GREEN, YELLOW, RED, UNKNOWN
+}
+
+// query encodes the filter as URL query parameters.
+func (f *TemplateFilter) query() neturl.Values {
+	values := neturl.Values{}
+	if f == nil {
+		return values
+	}
+
+	f.ListOptions.apply(values)
+	if f.Name != "" {
+		values.Set("name", f.Name)
+	}
+	if f.Status != "" {
+		values.Set("status", f.Status)
+	}
+	if f.Category != "" {
+		values.Set("category", f.Category)
+	}
+	if f.Language != "" {
+		values.Set("language", f.Language)
+	}
+	if f.QualityScore != "" {
+		values.Set("quality_score", f.QualityScore)
+	}
+
+	return values
+}
+
+// ListTemplates returns an iterator over all templates matching the filter.
+func (c *Client) ListTemplates(ctx context.Context, filter *TemplateFilter) *Iterator[models.Template] {
+	if c.config.BusinessAccountID == "" {
+		return errIterator[models.Template](fmt.Errorf("BusinessAccountID is required for this operation"))
+	}
+
+	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	return newIterator[models.Template](ctx, c, url, filter.query())
+}
+
+// CreateTemplateRequest contains the data for creating a new template.
+type CreateTemplateRequest struct {
+	Name            string                        `json:"name"`
+	Category        string                        `json:"category"` // MARKETING, UTILITY, AUTHENTICATION
+	Language        string                        `json:"language"`
+	ParameterFormat string                        `json:"parameter_format,omitempty"` // POSITIONAL (default) or NAMED
+	Components      []models.TemplateComponentDef `json:"components"`
+}
+
+// Validate checks the template definition locally before submission.
+// See templates.Validate for the rules that are applied.
+func (r *CreateTemplateRequest) Validate() error {
+	return templates.Validate(&models.Template{
+		Name:            r.Name,
+		Category:        r.Category,
+		Language:        r.Language,
+		ParameterFormat: r.ParameterFormat,
+		Components:      r.Components,
+	})
+}
+
+// CreateTemplate validates and creates a new message template.
+func (c *Client) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*models.Template, error) {
+	if c.config.BusinessAccountID == "" {
+		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+	if req == nil {
+		return nil, errors.NewValidationError("request", "request is required")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	var resp models.Template
+	if err := c.Post(ctx, url, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteTemplate deletes a message template.
+func (c *Client) DeleteTemplate(ctx context.Context, templateName string) error {
+	if c.config.BusinessAccountID == "" {
+		return fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+
+	url := fmt.Sprintf("%s/%s/message_templates?name=%s", c.config.GetAPIURL(), c.config.BusinessAccountID, templateName)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Delete(ctx, url, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to delete template")
+	}
+
+	return nil
+}
+
+// DeleteTemplateByID deletes a single template by its ID (hsm_id).
+// Unlike DeleteTemplate, other languages with the same name are kept.
+func (c *Client) DeleteTemplateByID(ctx context.Context, templateID, templateName string) error {
+	if c.config.BusinessAccountID == "" {
+		return fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+	if templateID == "" {
+		return errors.NewValidationError("templateID", "template ID is required")
+	}
+	if templateName == "" {
+		return errors.NewValidationError("templateName", "template name is required")
+	}
+
+	query := neturl.Values{}
+	query.Set("hsm_id", templateID)
+	query.Set("name", templateName)
+
+	url := fmt.Sprintf("%s/%s/message_templates?%s", c.config.GetAPIURL(), c.config.BusinessAccountID, query.Encode())
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Delete(ctx, url, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to delete template")
+	}
+
+	return nil
+}
+
+// Edit limits for approved templates
+const (
+	MaxApprovedTemplateEditsPerDay   = 1
+	MaxApprovedTemplateEditsPerMonth = 10
+)
+
+// UpdateTemplateRequest contains the changes to apply to an existing template.
+// Only non-empty fields are sent.
+type UpdateTemplateRequest struct {
+	Category   string                        `json:"category,omitempty"`
+	Components []models.TemplateComponentDef `json:"components,omitempty"`
+}
+
+// UpdateTemplate edits the category and/or components of an existing template.
+//
+// Only APPROVED, REJECTED and PAUSED templates can be edited, and the category
+// of an approved template cannot be changed. Approved templates can be edited
+// once per 24 hours and 10 times per 30 days; these limits are enforced by the
+// API unless a TemplateEditStore is set with WithTemplateEditStore, in which
+// case edits recorded in the store are checked locally first.
+func (c *Client) UpdateTemplate(ctx context.Context, templateID string, req *UpdateTemplateRequest) (err error) {
+	if templateID == "" {
+		return errors.NewValidationError("templateID", "template ID is required")
+	}
+	if req == nil || (req.Category == "" && len(req.Components) == 0) {
+		return errors.NewValidationError("template", "category or components are required")
+	}
+
+	current, err := c.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.validateTemplateEdit(current, req); err != nil {
+		return err
+	}
+
+	if current.Status == models.TemplateStatusApproved && c.templateEdits != nil {
+		release, reserveErr := c.templateEdits.Reserve(ctx, templateID, time.Now())
+		if reserveErr != nil {
+			return reserveErr
+		}
+		// Only successful edits count towards the limits
+		defer func() {
+			if err != nil {
+				release()
+			}
+		}()
+	}
+
+	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), templateID)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, req, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to update template")
+	}
+
+	return nil
+}
+
+// validateTemplateEdit checks the edit restrictions for the current template state.
+func (c *Client) validateTemplateEdit(current *models.Template, req *UpdateTemplateRequest) error {
+	if len(req.Components) > 0 {
+		category := req.Category
+		if category == "" {
+			category = current.Category
+		}
+		var errs errors.ValidationErrors
+		templates.ValidateComponents(&errs, category, current.ParameterFormat, req.Components)
+		if err := errs.ErrOrNil(); err != nil {
+			return err
+		}
+	}
+
+	switch current.Status {
+	case models.TemplateStatusApproved, models.TemplateStatusRejected, models.TemplateStatusPaused:
+	default:
+		return errors.NewValidationError("status", fmt.Sprintf("templates with status %s cannot be edited", current.Status))
+	}
+
+	if current.Status != models.TemplateStatusApproved {
+		return nil
+	}
+
+	if req.Category != "" && req.Category != current.Category {
+		return errors.NewValidationError("category", "the category of an approved template cannot be changed")
+	}
+
+	return nil
+}
+
+// TemplateEditStore records edits of approved templates so UpdateTemplate
+// can enforce the edit limits before calling the API. The limits are only
+// as accurate as the store: edits made outside it, e.g. in WhatsApp
+// Manager, are not counted. Implementations must be safe for concurrent
+// use; a store shared by several processes must check and record an edit
+// atomically.
+type TemplateEditStore interface {
+	// Reserve records an edit of templateID at t if CheckTemplateEditLimits
+	// allows it, and returns a function that removes the edit again.
+	Reserve(ctx context.Context, templateID string, t time.Time) (release func(), err error)
+}
+
+// CheckTemplateEditLimits checks whether an approved template with the given
+// earlier edits may be edited at now, and returns a ValidationError if not.
+func CheckTemplateEditLimits(edits []time.Time, now time.Time) error {
+	var lastDay, lastMonth int
+	for _, t := range edits {
+		if now.Sub(t) < 30*24*time.Hour {
+			lastMonth++
+		}
+		if now.Sub(t) < 24*time.Hour {
+			lastDay++
+		}
+	}
+
+	if lastDay >= MaxApprovedTemplateEditsPerDay {
+		return errors.NewValidationError("template", "approved templates can only be edited once per 24 hours")
+	}
+	if lastMonth >= MaxApprovedTemplateEditsPerMonth {
+		return errors.NewValidationError("template", fmt.Sprintf("approved templates can only be edited %d times per 30 days", MaxApprovedTemplateEditsPerMonth))
+	}
+	return nil
+}
+
+// MemoryTemplateEditStore is a TemplateEditStore that keeps edits in
+// memory. It only counts edits made through clients sharing it in one
+// process since it was created.
+type MemoryTemplateEditStore struct {
+	edits map[string][]time.Time
+	mu    sync.Mutex
+}
+
+// NewMemoryTemplateEditStore creates an empty MemoryTemplateEditStore.
+func NewMemoryTemplateEditStore() *MemoryTemplateEditStore {
+	return &MemoryTemplateEditStore{edits: make(map[string][]time.Time)}
+}
+
+// Reserve implements TemplateEditStore.
+func (s *MemoryTemplateEditStore) Reserve(ctx context.Context, templateID string, t time.Time) (func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(t)
+	if err := CheckTemplateEditLimits(s.edits[templateID], t); err != nil {
+		return nil, err
+	}
+	s.edits[templateID] = append(s.edits[templateID], t)
+
+	release := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		edits := s.edits[templateID]
+		for i, edit := range edits {
+			if edit.Equal(t) {
+				s.edits[templateID] = append(edits[:i], edits[i+1:]...)
+				break
+			}
+		}
+		if len(s.edits[templateID]) == 0 {
+			delete(s.edits, templateID)
+		}
+	}
+
+	return release, nil
+}
+
+// prune drops edits older than the 30 day window, and templates left
+// without edits, so the store does not grow without bound.
+func (s *MemoryTemplateEditStore) prune(now time.Time) {
+	for id, edits := range s.edits {
+		kept := edits[:0]
+		for _, t := range edits {
+			if now.Sub(t) < 30*24*time.Hour {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.edits, id)
+		} else {
+			s.edits[id] = kept
+		}
+	}
+}
+
+// LibraryButtonInput customizes a button of a template library template.
+type LibraryButtonInput struct {
+	Type        string           `json:"type"` // URL, PHONE_NUMBER
+	URL         *LibraryURLInput `json:"url,omitempty"`
+	PhoneNumber string           `json:"phone_number,omitempty"`
+}
+
+// LibraryURLInput contains the URL of a library template URL button.
+type LibraryURLInput struct {
+	BaseURL          string `json:"base_url"`
+	URLSuffixExample string `json:"url_suffix_example,omitempty"`
+}
+
+// LibraryBodyInputs contains the optional body settings of a library template.
+type LibraryBodyInputs struct {
+	AddContactNumber          bool `json:"add_contact_number,omitempty"`
+	AddLearnMoreLink          bool `json:"add_learn_more_link,omitempty"`
+	AddSecurityRecommendation bool `json:"add_security_recommendation,omitempty"`
+	AddTrackPackageLink       bool `json:"add_track_package_link,omitempty"`
+	CodeExpirationMinutes     int  `json:"code_expiration_minutes,omitempty"`
+}
+
+// CreateTemplateFromLibraryRequest contains the data for creating a template
+// from Meta's template library.
+type CreateTemplateFromLibraryRequest struct {
+	Name                        string               `json:"name"`
+	Category                    string               `json:"category"`
+	Language                    string               `json:"language"`
+	LibraryTemplateName         string               `json:"library_template_name"`
+	LibraryTemplateButtonInputs []LibraryButtonInput `json:"library_template_button_inputs,omitempty"`
+	LibraryTemplateBodyInputs   *LibraryBodyInputs   `json:"library_template_body_inputs,omitempty"`
+}
+
+// CreateTemplateFromLibrary creates a new template based on a template library template.
+func (c *Client) CreateTemplateFromLibrary(ctx context.Context, req *CreateTemplateFromLibraryRequest) (*models.Template, error) {
+	if c.config.BusinessAccountID == "" {
+		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+	if req == nil {
+		return nil, errors.NewValidationError("request", "request is required")
+	}
+	if req.Name == "" {
+		return nil, errors.NewValidationError("name", "template name is required")
+	}
+	if req.Language == "" {
+		return nil, errors.NewValidationError("language", "language is required")
+	}
+	if req.LibraryTemplateName == "" {
+		return nil, errors.NewValidationError("library_template_name", "library template name is required")
+	}
+
+	url := fmt.Sprintf("%s/%s/message_templates", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	var resp models.Template
+	if err := c.Post(ctx, url, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ===============================
+// Flows
+// ===============================
+
+// defaultFlowFields are the fields requested by GetFlow and ListFlows.
+const defaultFlowFields = "id,name,status,categories,validation_errors,json_version,data_api_version,endpoint_uri"
+
+// validFlowCategories are the categories accepted by the API.
+var validFlowCategories = map[string]bool{
+	models.FlowCategorySignUp:             true,
+	models.FlowCategorySignIn:             true,
+	models.FlowCategoryAppointmentBooking: true,
+	models.FlowCategoryLeadGeneration:     true,
+	models.FlowCategoryContactUs:          true,
+	models.FlowCategoryCustomerSupport:    true,
+	models.FlowCategorySurvey:             true,
+	models.FlowCategoryOther:              true,
+}
+
+// CreateFlowRequest contains the data for creating a new flow.
+type CreateFlowRequest struct {
+	Name        string   `json:"name"`
+	Categories  []string `json:"categories"`
+	CloneFlowID string   `json:"clone_flow_id,omitempty"`
+	EndpointURI string   `json:"endpoint_uri,omitempty"`
+	FlowJSON    string   `json:"flow_json,omitempty"` // Optional Flow JSON to upload with the flow
+	Publish     bool     `json:"publish,omitempty"`   // Publish immediately; requires FlowJSON
+}
+
+// Validate checks the request locally before submission.
+func (r *CreateFlowRequest) Validate() error {
+	var errs errors.ValidationErrors
+
+	if r.Name == "" {
+		errs.Add("name", "flow name is required")
+	}
+	validateFlowCategories(&errs, r.Categories)
+	if r.FlowJSON != "" && !json.Valid([]byte(r.FlowJSON)) {
+		errs.Add("flow_json", "flow JSON is not valid JSON")
+	}
+	if r.Publish && r.FlowJSON == "" {
+		errs.Add("publish", "flow JSON is required to publish on creation")
+	}
+
+	return errs.ErrOrNil()
+}
+
+// CreateFlow creates a new flow in draft status. Validation errors of the
+// Flow JSON are returned in the response; the flow is still created.
+func (c *Client) CreateFlow(ctx context.Context, req *CreateFlowRequest) (*models.FlowCreateResponse, error) {
+	if c.config.BusinessAccountID == "" {
+		return nil, fmt.Errorf("BusinessAccountID is required for this operation")
+	}
+	if req == nil {
+		return nil, errors.NewValidationError("request", "request is required")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s/flows", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	var resp models.FlowCreateResponse
+	if err := c.Post(ctx, url, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetFlow retrieves a flow by ID.
+func (c *Client) GetFlow(ctx context.Context, flowID string) (*models.Flow, error) {
+	if flowID == "" {
+		return nil, errors.NewValidationError("flowID", "flow ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s?fields=%s", c.config.GetAPIURL(), flowID, defaultFlowFields)
+
+	var resp models.Flow
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListFlows returns an iterator over all flows of the business account.
+func (c *Client) ListFlows(ctx context.Context, opts *ListOptions) *Iterator[models.Flow] {
+	if c.config.BusinessAccountID == "" {
+		return errIterator[models.Flow](fmt.Errorf("BusinessAccountID is required for this operation"))
+	}
+
+	url := fmt.Sprintf("%s/%s/flows", c.config.GetAPIURL(), c.config.BusinessAccountID)
+
+	query := neturl.Values{}
+	query.Set("fields", defaultFlowFields)
+	opts.apply(query)
+
+	return newIterator[models.Flow](ctx, c, url, query)
+}
+
+// UpdateFlowRequest contains the flow metadata to update. Empty fields are
+// left unchanged.
+type UpdateFlowRequest struct {
+	Name          string   `json:"name,omitempty"`
+	Categories    []string `json:"categories,omitempty"`
+	EndpointURI   string   `json:"endpoint_uri,omitempty"`
+	ApplicationID string   `json:"application_id,omitempty"`
+}
+
+// UpdateFlowMetadata updates the name, categories or endpoint of a flow.
+func (c *Client) UpdateFlowMetadata(ctx context.Context, flowID string, req *UpdateFlowRequest) error {
+	if flowID == "" {
+		return errors.NewValidationError("flowID", "flow ID is required")
+	}
+	if req == nil {
+		return errors.NewValidationError("request", "request is required")
+	}
+	if len(req.Categories) > 0 {
+		var errs errors.ValidationErrors
+		validateFlowCategories(&errs, req.Categories)
+		if err := errs.ErrOrNil(); err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), flowID)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, req, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to update flow")
+	}
+
+	return nil
+}
+
+// UpdateFlowJSON uploads the Flow JSON of a draft flow. The JSON is saved
+// even when it has validation errors; those are returned so they can be
+// fixed before publishing.
+func (c *Client) UpdateFlowJSON(ctx context.Context, flowID string, flowJSON []byte) (models.FlowValidationErrors, error) {
+	if flowID == "" {
+		return nil, errors.NewValidationError("flowID", "flow ID is required")
+	}
+	if !json.Valid(flowJSON) {
+		return nil, errors.NewValidationError("flowJSON", "flow JSON is not valid JSON")
+	}
+
+	// Create multipart form
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("name", "flow.json"); err != nil {
+		return nil, fmt.Errorf("failed to write name field: %w", err)
+	}
+	if err := writer.WriteField("asset_type", "FLOW_JSON"); err != nil {
+		return nil, fmt.Errorf("failed to write asset_type field: %w", err)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="flow.json"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := part.Write(flowJSON); err != nil {
+		return nil, fmt.Errorf("failed to write flow JSON: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	// Create request
+	url := fmt.Sprintf("%s/%s/assets", c.config.GetAPIURL(), flowID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Execute request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, c.parseError(respBody, resp.StatusCode)
+	}
+
+	var result struct {
+		Success          bool                        `json:"success"`
+		ValidationErrors models.FlowValidationErrors `json:"validation_errors"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !result.Success {
+		return result.ValidationErrors, fmt.Errorf("failed to update flow JSON")
+	}
+
+	return result.ValidationErrors, nil
+}
+
+// GetFlowAssets lists the assets of a flow. The Flow JSON can be downloaded
+// from its DownloadURL.
+func (c *Client) GetFlowAssets(ctx context.Context, flowID string) ([]models.FlowAsset, error) {
+	if flowID == "" {
+		return nil, errors.NewValidationError("flowID", "flow ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s/assets", c.config.GetAPIURL(), flowID)
+
+	var resp struct {
+		Data []models.FlowAsset `json:"data"`
+	}
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// GetFlowPreview returns a web preview link of a flow. Set invalidate to
+// generate a new link and expire the previous one.
+func (c *Client) GetFlowPreview(ctx context.Context, flowID string, invalidate bool) (*models.FlowPreview, error) {
+	if flowID == "" {
+		return nil, errors.NewValidationError("flowID", "flow ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s?fields=preview.invalidate(%t)", c.config.GetAPIURL(), flowID, invalidate)
+
+	var resp models.Flow
+	if err := c.Get(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Preview == nil {
+		return nil, fmt.Errorf("no preview returned for flow %s", flowID)
+	}
+
+	return resp.Preview, nil
+}
+
+// PublishFlow publishes a draft flow. Published flows can no longer be edited.
+func (c *Client) PublishFlow(ctx context.Context, flowID string) error {
+	return c.flowAction(ctx, flowID, "publish")
+}
+
+// DeprecateFlow deprecates a published flow so it can no longer be sent.
+func (c *Client) DeprecateFlow(ctx context.Context, flowID string) error {
+	return c.flowAction(ctx, flowID, "deprecate")
+}
+
+// DeleteFlow deletes a draft flow. Published flows must be deprecated instead.
+func (c *Client) DeleteFlow(ctx context.Context, flowID string) error {
+	if flowID == "" {
+		return errors.NewValidationError("flowID", "flow ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s", c.config.GetAPIURL(), flowID)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Delete(ctx, url, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to delete flow")
+	}
+
+	return nil
+}
+
+// flowAction performs a lifecycle action (publish, deprecate) on a flow.
+func (c *Client) flowAction(ctx context.Context, flowID, action string) error {
+	if flowID == "" {
+		return errors.NewValidationError("flowID", "flow ID is required")
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", c.config.GetAPIURL(), flowID, action)
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, nil, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to %s flow", action)
+	}
+
+	return nil
+}
+
+// validateFlowCategories checks that at least one known category is set.
+func validateFlowCategories(errs *errors.ValidationErrors, categories []string) {
+	if len(categories) == 0 {
+		errs.Add("categories", "at least one category is required")
+	}
+	for i, category := range categories {
+		if !validFlowCategories[category] {
+			errs.Add(fmt.Sprintf("categories[%d]", i), fmt.Sprintf("unknown flow category %q", category))
+		}
+	}
+}
+
+// ===============================
+// Two-Step Verification
+// ===============================
+
+// SetTwoStepVerificationPin sets the two-step verification PIN.
+func (c *Client) SetTwoStepVerificationPin(ctx context.Context, pin string) error {
+	if len(pin) != 6 {
+		return fmt.Errorf("PIN must be exactly 6 digits")
+	}
+
+	url := c.config.GetAPIURL() + "/" + c.config.PhoneNumberID
+
+	body := map[string]string{"pin": pin}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, body, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to set two-step verification PIN")
+	}
+
+	return nil
+}
+
+// ===============================
+// Flows Encryption
+// ===============================
+
+// SetFlowsEncryptionPublicKey uploads the PEM encoded public key used to
+// encrypt requests sent to the Flows data endpoint of this phone number.
+func (c *Client) SetFlowsEncryptionPublicKey(ctx context.Context, publicKeyPEM string) error {
+	if publicKeyPEM == "" {
+		return errors.NewValidationError("publicKeyPEM", "public key is required")
+	}
+
+	url := c.config.GetAPIURL() + "/" + c.config.PhoneNumberID + "/whatsapp_business_encryption"
+
+	body := map[string]string{"business_public_key": publicKeyPEM}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, body, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to set flows encryption public key")
+	}
+
+	return nil
+}
+
+// ===============================
+// Message Read Status
+// ===============================
+
+// MarkMessageAsRead marks a message as read.
+func (c *Client) MarkMessageAsRead(ctx context.Context, messageID string) error {
+	return c.updateReadStatus(ctx, messageID, false)
+}
+
+// SendTypingIndicator marks a message as read and shows a typing indicator
+// to its sender. The indicator is dismissed when a reply is sent or after
+// 25 seconds, whichever comes first.
+func (c *Client) SendTypingIndicator(ctx context.Context, messageID string) error {
+	return c.updateReadStatus(ctx, messageID, true)
+}
+
+// replyNotBeforeKey is the context key of WithReplyNotBefore.
+type replyNotBeforeKey struct{}
+
+// WithReplyNotBefore returns a context that delays messages sent with it
+// until t, e.g. so a typing indicator stays visible for a minimum time.
+// Messages sent after t are not delayed.
+func WithReplyNotBefore(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, replyNotBeforeKey{}, t)
+}
+
+// waitReplyNotBefore waits until the time set with WithReplyNotBefore, if any.
+func waitReplyNotBefore(ctx context.Context) error {
+	t, ok := ctx.Value(replyNotBeforeKey{}).(time.Time)
+	if !ok {
+		return nil
+	}
+	wait := time.Until(t)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// updateReadStatus marks a message as read, optionally with a typing indicator.
+func (c *Client) updateReadStatus(ctx context.Context, messageID string, typing bool) error {
+	if messageID == "" {
+		return fmt.Errorf("messageID is required")
+	}
+
+	url := c.config.GetMessagesURL()
+
+	body := map[string]interface{}{
+		"messaging_product": models.MessagingProduct,
+		"status":            "read",
+		"message_id":        messageID,
+	}
+	if typing {
+		body["typing_indicator"] = map[string]string{"type": "text"}
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	if err := c.Post(ctx, url, body, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		if typing {
+			return fmt.Errorf("failed to send typing indicator")
+		}
+		return fmt.Errorf("failed to mark message as read")
+	}
+
+	return nil
+}
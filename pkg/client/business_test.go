@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// templateServer serves one template and counts the edits posted to it.
+type templateServer struct {
+	status   string
+	category string
+	fail     bool
+	edits    int
+}
+
+func (s *templateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v18.0/tpl" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method == http.MethodGet {
+		fmt.Fprintf(w, `{"id":"tpl","name":"order","status":%q,"category":%q,"language":"en_US"}`, s.status, s.category)
+		return
+	}
+	if s.fail {
+		http.Error(w, `{"error":{"message":"temporarily unavailable","code":2}}`, http.StatusServiceUnavailable)
+		return
+	}
+	s.edits++
+	fmt.Fprint(w, `{"success":true}`)
+}
+
+func TestValidateTemplateEdit(t *testing.T) {
+	body := []models.TemplateComponentDef{{Type: "BODY", Text: "Your order has shipped"}}
+
+	tests := []struct {
+		name      string
+		status    string
+		req       *UpdateTemplateRequest
+		wantField string
+	}{
+		{"approved components", models.TemplateStatusApproved, &UpdateTemplateRequest{Components: body}, ""},
+		{"approved same category", models.TemplateStatusApproved, &UpdateTemplateRequest{Category: models.TemplateCategoryUtility}, ""},
+		{"approved new category", models.TemplateStatusApproved, &UpdateTemplateRequest{Category: models.TemplateCategoryMarketing}, "category"},
+		{"rejected new category", models.TemplateStatusRejected, &UpdateTemplateRequest{Category: models.TemplateCategoryMarketing}, ""},
+		{"paused", models.TemplateStatusPaused, &UpdateTemplateRequest{Components: body}, ""},
+		{"pending", models.TemplateStatusPending, &UpdateTemplateRequest{Components: body}, "status"},
+		{"disabled", models.TemplateStatusDisabled, &UpdateTemplateRequest{Components: body}, "status"},
+		{"invalid components", models.TemplateStatusApproved, &UpdateTemplateRequest{Components: []models.TemplateComponentDef{{Type: "FOOTER", Text: "footer only"}}}, "components"},
+	}
+
+	c := &Client{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &models.Template{ID: "tpl", Status: tt.status, Category: models.TemplateCategoryUtility}
+			err := c.validateTemplateEdit(current, tt.req)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("validateTemplateEdit: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateTemplateEdit = nil, want an error for %s", tt.wantField)
+			}
+			if !containsField(err, tt.wantField) {
+				t.Fatalf("validateTemplateEdit = %v, want an error for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	req := &UpdateTemplateRequest{Components: []models.TemplateComponentDef{{Type: "BODY", Text: "Your order has shipped"}}}
+
+	t.Run("rejects invalid edits before posting", func(t *testing.T) {
+		srv := &templateServer{status: models.TemplateStatusPending, category: models.TemplateCategoryUtility}
+		c := newTestClient(t, srv)
+
+		if err := c.UpdateTemplate(context.Background(), "tpl", req); !containsField(err, "status") {
+			t.Fatalf("UpdateTemplate = %v, want a status error", err)
+		}
+		if srv.edits != 0 {
+			t.Fatalf("%d edits posted, want 0", srv.edits)
+		}
+	})
+
+	t.Run("leaves limits to the API without a store", func(t *testing.T) {
+		srv := &templateServer{status: models.TemplateStatusApproved, category: models.TemplateCategoryUtility}
+		c := newTestClient(t, srv)
+
+		for i := 0; i < 2; i++ {
+			if err := c.UpdateTemplate(context.Background(), "tpl", req); err != nil {
+				t.Fatalf("UpdateTemplate %d: %v", i, err)
+			}
+		}
+		if srv.edits != 2 {
+			t.Fatalf("%d edits posted, want 2", srv.edits)
+		}
+	})
+
+	t.Run("enforces limits with a store", func(t *testing.T) {
+		srv := &templateServer{status: models.TemplateStatusApproved, category: models.TemplateCategoryUtility}
+		c := newTestClient(t, srv)
+		WithTemplateEditStore(NewMemoryTemplateEditStore())(c)
+
+		if err := c.UpdateTemplate(context.Background(), "tpl", req); err != nil {
+			t.Fatalf("first UpdateTemplate: %v", err)
+		}
+		if err := c.UpdateTemplate(context.Background(), "tpl", req); !containsField(err, "template") {
+			t.Fatalf("second UpdateTemplate = %v, want a template limit error", err)
+		}
+		if srv.edits != 1 {
+			t.Fatalf("%d edits posted, want 1", srv.edits)
+		}
+	})
+
+	t.Run("failed edits do not count", func(t *testing.T) {
+		srv := &templateServer{status: models.TemplateStatusApproved, category: models.TemplateCategoryUtility, fail: true}
+		c := newTestClient(t, srv)
+		WithTemplateEditStore(NewMemoryTemplateEditStore())(c)
+
+		if err := c.UpdateTemplate(context.Background(), "tpl", req); err == nil {
+			t.Fatal("UpdateTemplate against a failing API = nil, want an error")
+		}
+		srv.fail = false
+		if err := c.UpdateTemplate(context.Background(), "tpl", req); err != nil {
+			t.Fatalf("UpdateTemplate after a failed edit: %v", err)
+		}
+	})
+}
+
+func TestCheckTemplateEditLimits(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days ...int) []time.Time {
+		var edits []time.Time
+		for _, d := range days {
+			edits = append(edits, now.Add(-time.Duration(d)*24*time.Hour-time.Minute))
+		}
+		return edits
+	}
+
+	tests := []struct {
+		name    string
+		edits   []time.Time
+		wantErr bool
+	}{
+		{"no edits", nil, false},
+		{"edited yesterday", daysAgo(1), false},
+		{"edited today", []time.Time{now.Add(-time.Hour)}, true},
+		{"nine edits this month", daysAgo(1, 2, 3, 4, 5, 6, 7, 8, 9), false},
+		{"ten edits this month", daysAgo(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), true},
+		{"ten edits spanning two months", daysAgo(1, 2, 3, 4, 5, 6, 7, 8, 9, 30), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTemplateEditLimits(tt.edits, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckTemplateEditLimits = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemoryTemplateEditStorePrunes(t *testing.T) {
+	store := NewMemoryTemplateEditStore()
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	if _, err := store.Reserve(context.Background(), "old", now.Add(-31*24*time.Hour)); err != nil {
+		t.Fatalf("Reserve old: %v", err)
+	}
+	release, err := store.Reserve(context.Background(), "new", now)
+	if err != nil {
+		t.Fatalf("Reserve new: %v", err)
+	}
+	if _, ok := store.edits["old"]; ok {
+		t.Fatal("edits older than 30 days were kept")
+	}
+
+	release()
+	if len(store.edits) != 0 {
+		t.Fatalf("edits after release = %v, want none", store.edits)
+	}
+}
+
+// containsField reports whether err is a validation error for field or
+// one of its elements.
+func containsField(err error, field string) bool {
+	var verrs errors.ValidationErrors
+	var verr *errors.ValidationError
+	switch {
+	case stderrors.As(err, &verrs):
+	case stderrors.As(err, &verr):
+		verrs = errors.ValidationErrors{verr}
+	}
+	for _, e := range verrs {
+		if strings.HasPrefix(e.Field, field) {
+			return true
+		}
+	}
+	return false
+}
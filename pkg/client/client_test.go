@@ -0,0 +1,36 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"object":"whatsapp_business_account"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		want      bool
+	}{
+		{"with prefix", "secret", "sha256=" + signature, true},
+		{"without prefix", "secret", signature, true},
+		{"wrong secret", "other", "sha256=" + signature, false},
+		{"no secret", "", "sha256=" + signature, false},
+		{"empty signature", "secret", "sha256=", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, payload, tt.signature); got != tt.want {
+				t.Fatalf("VerifySignature = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
-package client
-
-import "encoding/json"
-
-// Ensure json is imported for media.go
-var _ = json.Marshal
+package client
+
+import "encoding/json"
+
+// Ensure json is imported for media.go
+var _ = json.Marshal
@@ -0,0 +1,333 @@
+// Package client provides sending of text that exceeds the message length limit.
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// LongTextOptions configures SendLongText.
+type LongTextOptions struct {
+	// MaxLength is the maximum number of characters per message
+	// (models.MaxTextBodyLength if zero)
+	MaxLength int
+	// ReplyTo sends every part as a reply to this message ID
+	ReplyTo string
+	// PreviewURL renders link previews in each part
+	PreviewURL bool
+}
+
+// SendLongText sends body as one or more text messages. Text longer than
+// the limit is split on paragraph, line, sentence or word boundaries, in
+// that order of preference, and never inside a character sequence that
+// renders as one symbol. Formatting such as *bold* that spans a split is
+// closed at the end of one part and reopened in the next. Parts are sent in
+// order, each only after the previous one succeeded; on failure the
+// responses of the parts already sent are returned with the error.
+func (c *Client) SendLongText(ctx context.Context, to, body string, opts *LongTextOptions) ([]*models.MessageResponse, error) {
+	if body == "" {
+		return nil, errors.NewValidationError("body", "message body is required")
+	}
+
+	maxLength := models.MaxTextBodyLength
+	var replyTo string
+	var previewURL bool
+	if opts != nil {
+		if opts.MaxLength < 0 || opts.MaxLength > models.MaxTextBodyLength {
+			return nil, errors.NewValidationError("maxLength", fmt.Sprintf("must be between 1 and %d", models.MaxTextBodyLength))
+		}
+		if opts.MaxLength > 0 {
+			maxLength = opts.MaxLength
+		}
+		replyTo = opts.ReplyTo
+		previewURL = opts.PreviewURL
+	}
+
+	parts := splitText(body, maxLength)
+	responses := make([]*models.MessageResponse, 0, len(parts))
+	for i, part := range parts {
+		req := models.MessageRequest{
+			MessagingProduct: models.MessagingProduct,
+			RecipientType:    "individual",
+			To:               to,
+			Type:             models.MessageTypeText,
+			Text: &models.TextContent{
+				Body:       part,
+				PreviewURL: previewURL,
+			},
+		}
+		if replyTo != "" {
+			req.Context = &models.Context{MessageID: replyTo}
+		}
+
+		resp, err := c.sendMessage(ctx, &req)
+		if err != nil {
+			if len(parts) == 1 {
+				return responses, err
+			}
+			return responses, fmt.Errorf("failed to send part %d of %d: %w", i+1, len(parts), err)
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+// ===============================
+// Splitting
+// ===============================
+
+// formatSpan is a pair of WhatsApp formatting markers. open is the index
+// of the opening marker and close the index of the closing marker.
+type formatSpan struct {
+	marker      string
+	open, close int
+}
+
+// splitText splits text into parts of at most maxLength characters,
+// counting the markers that close and reopen formatting cut by a split.
+func splitText(text string, maxLength int) []string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return []string{text}
+	}
+
+	spans := findFormatSpans(runes)
+
+	var parts []string
+	var prefix string
+	start := skipSpace(runes, 0)
+	for start < len(runes) {
+		end, open := fitPart(runes, spans, start, maxLength-utf8.RuneCountInString(prefix))
+
+		part := prefix + strings.TrimRightFunc(string(runes[start:end]), unicode.IsSpace)
+		prefix = ""
+		for i := len(open) - 1; i >= 0; i-- {
+			part += open[i].marker
+		}
+		for _, span := range open {
+			prefix += span.marker
+		}
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+
+		start = skipSpace(runes, end)
+	}
+
+	return parts
+}
+
+// fitPart returns where the part starting at start ends, and the spans it
+// cuts, so that the part and the markers closing those spans fit in room
+// characters. If room is too small for the markers, the part is cut
+// without closing them.
+func fitPart(runes []rune, spans []formatSpan, start, room int) (int, []formatSpan) {
+	if len(runes)-start <= room {
+		return len(runes), nil
+	}
+
+	// Cutting at a different position may cut other spans, so shrink the
+	// part until the markers it needs fit
+	reserve := 0
+	for room-reserve >= 1 {
+		end := findBreak(runes, spans, start, start+room-reserve)
+		open := openSpans(spans, end)
+		n := markerLength(open)
+		if n <= reserve {
+			return end, open
+		}
+		reserve = n
+	}
+
+	if room < 1 {
+		room = 1
+	}
+	return findBreak(runes, nil, start, start+room), nil
+}
+
+// markerLength returns the number of characters needed to close spans.
+func markerLength(spans []formatSpan) int {
+	n := 0
+	for _, span := range spans {
+		n += utf8.RuneCountInString(span.marker)
+	}
+	return n
+}
+
+// findBreak returns the best position in (start, max] to end a part at.
+// Breaks that leave the part at least half full and cut no formatting span
+// are preferred; only word and character breaks may leave it emptier.
+func findBreak(runes []rune, spans []formatSpan, start, max int) int {
+	levels := []func(i int) bool{
+		func(i int) bool { return i >= 2 && runes[i-1] == '\n' && runes[i-2] == '\n' },
+		func(i int) bool { return runes[i-1] == '\n' },
+		func(i int) bool { return unicode.IsSpace(runes[i]) && strings.ContainsRune(".!?…", runes[i-1]) },
+		func(i int) bool { return unicode.IsSpace(runes[i]) || unicode.IsSpace(runes[i-1]) },
+		func(i int) bool { return true },
+	}
+
+	for _, allowInSpan := range []bool{false, true} {
+		for level, isBreak := range levels {
+			last := level == len(levels)-1
+			if last && !allowInSpan {
+				continue
+			}
+			min := start + 1
+			if (level < 3 || !allowInSpan) && (max-start)/2 > 1 {
+				min = start + (max-start)/2
+			}
+			for i := max; i >= min; i-- {
+				if isBreak(i) && graphemeBoundary(runes, i) && !insideMarker(spans, i) &&
+					(allowInSpan || len(openSpans(spans, i)) == 0) {
+					return i
+				}
+			}
+		}
+	}
+
+	return max
+}
+
+// findFormatSpans finds paired formatting markers: ``` code blocks, `code`,
+// and *bold*, _italic_ and ~strikethrough~ within a line.
+func findFormatSpans(runes []rune) []formatSpan {
+	var spans []formatSpan
+
+	codeStart := -1
+	closers := make(map[int]bool)
+	for i := 0; i < len(runes); i++ {
+		if hasPrefixAt(runes, i, "```") {
+			if codeStart < 0 {
+				codeStart = i
+			} else {
+				spans = append(spans, formatSpan{marker: "```", open: codeStart, close: i})
+				codeStart = -1
+			}
+			i += 2
+			continue
+		}
+		if codeStart >= 0 {
+			continue
+		}
+
+		// Spans may nest, so keep scanning inside a span once it is found
+		marker := runes[i]
+		if closers[i] || !strings.ContainsRune("*_~`", marker) || !opensSpan(runes, i) {
+			continue
+		}
+		for j := i + 2; j < len(runes) && runes[j] != '\n'; j++ {
+			if runes[j] == marker && !closers[j] && closesSpan(runes, j) {
+				spans = append(spans, formatSpan{marker: string(marker), open: i, close: j})
+				closers[j] = true
+				break
+			}
+		}
+	}
+
+	return spans
+}
+
+// opensSpan reports whether the marker at i can open a span.
+func opensSpan(runes []rune, i int) bool {
+	if i+1 >= len(runes) || unicode.IsSpace(runes[i+1]) || runes[i+1] == runes[i] {
+		return false
+	}
+	return i == 0 || !isWordRune(runes[i-1])
+}
+
+// closesSpan reports whether the marker at i can close a span.
+func closesSpan(runes []rune, i int) bool {
+	if unicode.IsSpace(runes[i-1]) {
+		return false
+	}
+	return i+1 == len(runes) || !isWordRune(runes[i+1])
+}
+
+// openSpans returns the spans a break at position i would cut, outermost first.
+func openSpans(spans []formatSpan, i int) []formatSpan {
+	var open []formatSpan
+	for _, span := range spans {
+		if span.open < i && i <= span.close {
+			open = append(open, span)
+		}
+	}
+	return open
+}
+
+// insideMarker reports whether position i falls within a ``` marker.
+func insideMarker(spans []formatSpan, i int) bool {
+	for _, span := range spans {
+		n := len(span.marker)
+		if (i > span.open && i < span.open+n) || (i > span.close && i < span.close+n) {
+			return true
+		}
+	}
+	return false
+}
+
+// graphemeBoundary reports whether text may be split before runes[i]
+// without separating characters that render as one symbol: combining
+// marks, variation selectors, emoji modifiers and tags, zero width joiner
+// sequences, flag pairs and CR LF.
+func graphemeBoundary(runes []rune, i int) bool {
+	if i <= 0 || i >= len(runes) {
+		return true
+	}
+	prev, r := runes[i-1], runes[i]
+
+	switch {
+	case prev == '\r' && r == '\n':
+		return false
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return false
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+		return false
+	case r == 0x200D || prev == 0x200D:
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		// Flags are pairs of regional indicators; split only between pairs
+		n := 0
+		for j := i - 1; j >= 0 && isRegionalIndicator(runes[j]); j-- {
+			n++
+		}
+		return n%2 == 0
+	}
+
+	return true
+}
+
+// isRegionalIndicator reports whether r is one of the letters flags are made of.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isWordRune reports whether r is a letter or digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hasPrefixAt reports whether runes contains prefix at position i.
+func hasPrefixAt(runes []rune, i int, prefix string) bool {
+	for _, r := range prefix {
+		if i >= len(runes) || runes[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// skipSpace returns the index of the first non-space rune at or after i.
+func skipSpace(runes []rune, i int) int {
+	for i < len(runes) && unicode.IsSpace(runes[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		want      []string
+	}{
+		{"fits", "short text", 20, []string{"short text"}},
+		{"paragraphs", "First paragraph.\n\nSecond paragraph.", 20, []string{"First paragraph.", "Second paragraph."}},
+		{"sentences", "One sentence. Another one here.", 20, []string{"One sentence.", "Another one here."}},
+		{"words", "alpha beta gamma delta", 12, []string{"alpha beta", "gamma delta"}},
+		{"bold across split", "*bold word bold word*", 20, []string{"*bold word bold*", "*word*"}},
+		{"nested formatting", "*_bold italic words_*", 16, []string{"*_bold italic_*", "*_words_*"}},
+		{"code block", "```line one\nline two```", 16, []string{"```line one```", "```line two```"}},
+		{"flags", "🇩🇪🇫🇷🇮🇹", 3, []string{"🇩🇪", "🇫🇷", "🇮🇹"}},
+		{"zero width joiner", "ab 👨‍👩‍👧", 5, []string{"ab", "👨‍👩‍👧"}},
+		{"combining mark", "cafe\u0301 cafe\u0301", 5, []string{"cafe\u0301", "cafe\u0301"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitText(tt.text, tt.maxLength)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("splitText(%q, %d) = %q, want %q", tt.text, tt.maxLength, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTextLimits(t *testing.T) {
+	texts := []string{
+		strings.Repeat("*bold word* _italic_ ~struck~ plain text. ", 10),
+		strings.Repeat("*bold _bold italic ~all three~ words_ more* ", 8),
+		"```\n" + strings.Repeat("code line\n", 20) + "```",
+		strings.Repeat("👍🏽 e\u0301 🇯🇵 ", 20),
+		strings.Repeat("x", 100),
+	}
+
+	for _, text := range texts {
+		for maxLength := 1; maxLength <= 60; maxLength++ {
+			parts := splitText(text, maxLength)
+			for i, part := range parts {
+				if n := utf8.RuneCountInString(part); n > maxLength {
+					t.Fatalf("splitText(%q, %d) part %d has %d characters: %q", text, maxLength, i, n, part)
+				}
+			}
+			if got, want := stripMarkup(strings.Join(parts, "")), stripMarkup(text); got != want {
+				t.Fatalf("splitText(%q, %d) lost text: got %q, want %q", text, maxLength, got, want)
+			}
+		}
+	}
+}
+
+// stripMarkup removes formatting markers and whitespace, leaving the text
+// that must survive a split.
+func stripMarkup(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || strings.ContainsRune("*_~`", r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -1,432 +1,978 @@
-// Package client provides message sending functionality.
-package client
-
-import (
-	"context"
-	"fmt"
-
-	"github.com/yourusername/whatsapp-go/pkg/errors"
-	"github.com/yourusername/whatsapp-go/pkg/models"
-)
-
-// ===============================
-// Text Messages
-// ===============================
-
-// SendText sends a text message to a recipient.
-func (c *Client) SendText(ctx context.Context, to, body string, previewURL bool) (*models.MessageResponse, error) {
-	if to == "" {
-		return nil, errors.NewValidationError("to", "recipient phone number is required")
-	}
-	if body == "" {
-		return nil, errors.NewValidationError("body", "message body is required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeText,
-		Text: &models.TextContent{
-			Body:       body,
-			PreviewURL: previewURL,
-		},
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendTextReply sends a text message as a reply to another message.
-func (c *Client) SendTextReply(ctx context.Context, to, body, replyToMessageID string) (*models.MessageResponse, error) {
-	if replyToMessageID == "" {
-		return nil, errors.NewValidationError("replyToMessageID", "message ID to reply to is required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeText,
-		Context: &models.Context{
-			MessageID: replyToMessageID,
-		},
-		Text: &models.TextContent{
-			Body: body,
-		},
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// ===============================
-// Media Messages
-// ===============================
-
-// SendImage sends an image message.
-func (c *Client) SendImage(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
-	if err := validateMediaContent(media); err != nil {
-		return nil, err
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeImage,
-		Image:            media,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendVideo sends a video message.
-func (c *Client) SendVideo(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
-	if err := validateMediaContent(media); err != nil {
-		return nil, err
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeVideo,
-		Video:            media,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendAudio sends an audio message.
-func (c *Client) SendAudio(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
-	if err := validateMediaContent(media); err != nil {
-		return nil, err
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeAudio,
-		Audio:            media,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendDocument sends a document message.
-func (c *Client) SendDocument(ctx context.Context, to string, doc *models.DocumentContent) (*models.MessageResponse, error) {
-	if doc == nil {
-		return nil, errors.NewValidationError("document", "document content is required")
-	}
-	if doc.ID == "" && doc.Link == "" {
-		return nil, errors.NewValidationError("document", "either ID or Link is required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeDocument,
-		Document:         doc,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendSticker sends a sticker message.
-func (c *Client) SendSticker(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
-	if err := validateMediaContent(media); err != nil {
-		return nil, err
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeSticker,
-		Sticker:          media,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// ===============================
-// Location Messages
-// ===============================
-
-// SendLocation sends a location message.
-func (c *Client) SendLocation(ctx context.Context, to string, location *models.LocationContent) (*models.MessageResponse, error) {
-	if location == nil {
-		return nil, errors.NewValidationError("location", "location content is required")
-	}
-	if location.Latitude == 0 && location.Longitude == 0 {
-		return nil, errors.NewValidationError("location", "latitude and longitude are required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeLocation,
-		Location:         location,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// ===============================
-// Contact Messages
-// ===============================
-
-// SendContacts sends a contacts message.
-func (c *Client) SendContacts(ctx context.Context, to string, contacts []models.ContactContent) (*models.MessageResponse, error) {
-	if len(contacts) == 0 {
-		return nil, errors.NewValidationError("contacts", "at least one contact is required")
-	}
-
-	for i, contact := range contacts {
-		if contact.Name.FormattedName == "" {
-			return nil, errors.NewValidationError("contacts", fmt.Sprintf("contact %d: formatted_name is required", i))
-		}
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeContacts,
-		Contacts:         contacts,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// ===============================
-// Reaction Messages
-// ===============================
-
-// SendReaction sends a reaction to a message.
-func (c *Client) SendReaction(ctx context.Context, to, messageID, emoji string) (*models.MessageResponse, error) {
-	if messageID == "" {
-		return nil, errors.NewValidationError("messageID", "message ID is required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeReaction,
-		Reaction: &models.ReactionContent{
-			MessageID: messageID,
-			Emoji:     emoji, // Empty string removes reaction
-		},
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// RemoveReaction removes a reaction from a message.
-func (c *Client) RemoveReaction(ctx context.Context, to, messageID string) (*models.MessageResponse, error) {
-	return c.SendReaction(ctx, to, messageID, "")
-}
-
-// ===============================
-// Interactive Messages
-// ===============================
-
-// SendInteractiveButtons sends an interactive message with buttons.
-func (c *Client) SendInteractiveButtons(ctx context.Context, to, bodyText string, buttons []models.InteractiveButton, opts *InteractiveOptions) (*models.MessageResponse, error) {
-	if len(buttons) == 0 {
-		return nil, errors.NewValidationError("buttons", "at least one button is required")
-	}
-	if len(buttons) > 3 {
-		return nil, errors.NewValidationError("buttons", "maximum 3 buttons allowed")
-	}
-
-	interactive := &models.InteractiveContent{
-		Type: models.InteractiveTypeButton,
-		Body: models.InteractiveBody{Text: bodyText},
-		Action: models.InteractiveAction{
-			Buttons: buttons,
-		},
-	}
-
-	if opts != nil {
-		if opts.Header != nil {
-			interactive.Header = opts.Header
-		}
-		if opts.Footer != "" {
-			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
-		}
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeInteractive,
-		Interactive:      interactive,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendInteractiveList sends an interactive list message.
-func (c *Client) SendInteractiveList(ctx context.Context, to, bodyText, buttonText string, sections []models.InteractiveSection, opts *InteractiveOptions) (*models.MessageResponse, error) {
-	if len(sections) == 0 {
-		return nil, errors.NewValidationError("sections", "at least one section is required")
-	}
-	if buttonText == "" {
-		return nil, errors.NewValidationError("buttonText", "button text is required")
-	}
-
-	interactive := &models.InteractiveContent{
-		Type: models.InteractiveTypeList,
-		Body: models.InteractiveBody{Text: bodyText},
-		Action: models.InteractiveAction{
-			Button:   buttonText,
-			Sections: sections,
-		},
-	}
-
-	if opts != nil {
-		if opts.Header != nil {
-			interactive.Header = opts.Header
-		}
-		if opts.Footer != "" {
-			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
-		}
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeInteractive,
-		Interactive:      interactive,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendCTAButton sends a Call-to-Action URL button.
-func (c *Client) SendCTAButton(ctx context.Context, to, bodyText, displayText, url string, opts *InteractiveOptions) (*models.MessageResponse, error) {
-	if displayText == "" {
-		return nil, errors.NewValidationError("displayText", "display text is required")
-	}
-	if url == "" {
-		return nil, errors.NewValidationError("url", "URL is required")
-	}
-
-	interactive := &models.InteractiveContent{
-		Type: models.InteractiveTypeCTA,
-		Body: models.InteractiveBody{Text: bodyText},
-		Action: models.InteractiveAction{
-			Name: "cta_url",
-			Parameters: &models.CTAParameters{
-				DisplayText: displayText,
-				URL:         url,
-			},
-		},
-	}
-
-	if opts != nil {
-		if opts.Header != nil {
-			interactive.Header = opts.Header
-		}
-		if opts.Footer != "" {
-			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
-		}
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeInteractive,
-		Interactive:      interactive,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// InteractiveOptions contains optional settings for interactive messages.
-type InteractiveOptions struct {
-	Header *models.InteractiveHeader
-	Footer string
-}
-
-// ===============================
-// Template Messages
-// ===============================
-
-// SendTemplate sends a template message.
-func (c *Client) SendTemplate(ctx context.Context, to string, template *models.TemplateContent) (*models.MessageResponse, error) {
-	if template == nil {
-		return nil, errors.NewValidationError("template", "template content is required")
-	}
-	if template.Name == "" {
-		return nil, errors.NewValidationError("template.name", "template name is required")
-	}
-	if template.Language.Code == "" {
-		return nil, errors.NewValidationError("template.language.code", "language code is required")
-	}
-
-	req := models.MessageRequest{
-		MessagingProduct: models.MessagingProduct,
-		RecipientType:    "individual",
-		To:               to,
-		Type:             models.MessageTypeTemplate,
-		Template:         template,
-	}
-
-	return c.sendMessage(ctx, &req)
-}
-
-// SendSimpleTemplate sends a template message without parameters.
-func (c *Client) SendSimpleTemplate(ctx context.Context, to, templateName, languageCode string) (*models.MessageResponse, error) {
-	return c.SendTemplate(ctx, to, &models.TemplateContent{
-		Name: templateName,
-		Language: models.TemplateLanguage{
-			Code: languageCode,
-		},
-	})
-}
-
-// ===============================
-// Raw Message Sending
-// ===============================
-
-// SendMessage sends a custom message request.
-func (c *Client) SendMessage(ctx context.Context, req *models.MessageRequest) (*models.MessageResponse, error) {
-	return c.sendMessage(ctx, req)
-}
-
-// sendMessage is the internal method that actually sends the message.
-func (c *Client) sendMessage(ctx context.Context, req *models.MessageRequest) (*models.MessageResponse, error) {
-	if req.To == "" {
-		return nil, errors.NewValidationError("to", "recipient phone number is required")
-	}
-
-	var resp models.MessageResponse
-	err := c.Post(ctx, c.config.GetMessagesURL(), req, &resp)
-	if err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
-}
-
-// ===============================
-// Helper Functions
-// ===============================
-
-func validateMediaContent(media *models.MediaContent) error {
-	if media == nil {
-		return errors.NewValidationError("media", "media content is required")
-	}
-	if media.ID == "" && media.Link == "" {
-		return errors.NewValidationError("media", "either ID or Link is required")
-	}
-	return nil
-}
+// Package client provides message sending functionality.
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+	"github.com/yourusername/whatsapp-go/pkg/phone"
+	"github.com/yourusername/whatsapp-go/pkg/preview"
+	"github.com/yourusername/whatsapp-go/pkg/templates"
+)
+
+// ===============================
+// Text Messages
+// ===============================
+
+// SendText sends a text message to a recipient.
+func (c *Client) SendText(ctx context.Context, to, body string, previewURL bool) (*models.MessageResponse, error) {
+	if to == "" {
+		return nil, errors.NewValidationError("to", "recipient phone number is required")
+	}
+	if body == "" {
+		return nil, errors.NewValidationError("body", "message body is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeText,
+		Text: &models.TextContent{
+			Body:       body,
+			PreviewURL: previewURL,
+		},
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendTextReply sends a text message as a reply to another message.
+func (c *Client) SendTextReply(ctx context.Context, to, body, replyToMessageID string) (*models.MessageResponse, error) {
+	if replyToMessageID == "" {
+		return nil, errors.NewValidationError("replyToMessageID", "message ID to reply to is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeText,
+		Context: &models.Context{
+			MessageID: replyToMessageID,
+		},
+		Text: &models.TextContent{
+			Body: body,
+		},
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// ===============================
+// Media Messages
+// ===============================
+
+// SendImage sends an image message.
+func (c *Client) SendImage(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
+	if err := validateMediaContent(media); err != nil {
+		return nil, err
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeImage,
+		Image:            media,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendVideo sends a video message.
+func (c *Client) SendVideo(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
+	if err := validateMediaContent(media); err != nil {
+		return nil, err
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeVideo,
+		Video:            media,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendAudio sends an audio message.
+func (c *Client) SendAudio(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
+	if err := validateMediaContent(media); err != nil {
+		return nil, err
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeAudio,
+		Audio:            media,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendDocument sends a document message.
+func (c *Client) SendDocument(ctx context.Context, to string, doc *models.DocumentContent) (*models.MessageResponse, error) {
+	if doc == nil {
+		return nil, errors.NewValidationError("document", "document content is required")
+	}
+	if doc.ID == "" && doc.Link == "" && doc.Path == "" {
+		return nil, errors.NewValidationError("document", "either ID, Link or Path is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeDocument,
+		Document:         doc,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendSticker sends a sticker message.
+func (c *Client) SendSticker(ctx context.Context, to string, media *models.MediaContent) (*models.MessageResponse, error) {
+	if err := validateMediaContent(media); err != nil {
+		return nil, err
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeSticker,
+		Sticker:          media,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// ===============================
+// Location Messages
+// ===============================
+
+// SendLocation sends a location message.
+func (c *Client) SendLocation(ctx context.Context, to string, location *models.LocationContent) (*models.MessageResponse, error) {
+	if location == nil {
+		return nil, errors.NewValidationError("location", "location content is required")
+	}
+	if location.Latitude == 0 && location.Longitude == 0 {
+		return nil, errors.NewValidationError("location", "latitude and longitude are required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeLocation,
+		Location:         location,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// ===============================
+// Contact Messages
+// ===============================
+
+// SendContacts sends a contacts message.
+func (c *Client) SendContacts(ctx context.Context, to string, contacts []models.ContactContent) (*models.MessageResponse, error) {
+	if len(contacts) == 0 {
+		return nil, errors.NewValidationError("contacts", "at least one contact is required")
+	}
+
+	for i, contact := range contacts {
+		if contact.Name.FormattedName == "" {
+			return nil, errors.NewValidationError("contacts", fmt.Sprintf("contact %d: formatted_name is required", i))
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeContacts,
+		Contacts:         contacts,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// ===============================
+// Reaction Messages
+// ===============================
+
+// SendReaction sends a reaction to a message.
+func (c *Client) SendReaction(ctx context.Context, to, messageID, emoji string) (*models.MessageResponse, error) {
+	if messageID == "" {
+		return nil, errors.NewValidationError("messageID", "message ID is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeReaction,
+		Reaction: &models.ReactionContent{
+			MessageID: messageID,
+			Emoji:     emoji, // Empty string removes reaction
+		},
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// RemoveReaction removes a reaction from a message.
+func (c *Client) RemoveReaction(ctx context.Context, to, messageID string) (*models.MessageResponse, error) {
+	return c.SendReaction(ctx, to, messageID, "")
+}
+
+// ===============================
+// Interactive Messages
+// ===============================
+
+// SendInteractiveButtons sends an interactive message with buttons.
+func (c *Client) SendInteractiveButtons(ctx context.Context, to, bodyText string, buttons []models.InteractiveButton, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if len(buttons) == 0 {
+		return nil, errors.NewValidationError("buttons", "at least one button is required")
+	}
+	if len(buttons) > 3 {
+		return nil, errors.NewValidationError("buttons", "maximum 3 buttons allowed")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeButton,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Buttons: buttons,
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendInteractiveList sends an interactive list message.
+func (c *Client) SendInteractiveList(ctx context.Context, to, bodyText, buttonText string, sections []models.InteractiveSection, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if len(sections) == 0 {
+		return nil, errors.NewValidationError("sections", "at least one section is required")
+	}
+	if buttonText == "" {
+		return nil, errors.NewValidationError("buttonText", "button text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeList,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Button:   buttonText,
+			Sections: sections,
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendCTAButton sends a Call-to-Action URL button.
+func (c *Client) SendCTAButton(ctx context.Context, to, bodyText, displayText, url string, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if displayText == "" {
+		return nil, errors.NewValidationError("displayText", "display text is required")
+	}
+	if url == "" {
+		return nil, errors.NewValidationError("url", "URL is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeCTA,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name: "cta_url",
+			Parameters: &models.CTAParameters{
+				DisplayText: displayText,
+				URL:         url,
+			},
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendFlow sends an interactive flow message. The flow is identified by
+// FlowID or FlowName; FlowCTA is the text of the button that opens it.
+// FlowMessageVersion defaults to models.FlowMessageVersion.
+func (c *Client) SendFlow(ctx context.Context, to, bodyText string, flow *models.ActionParameters, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if flow == nil {
+		return nil, errors.NewValidationError("flow", "flow parameters are required")
+	}
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+	if flow.FlowID == "" && flow.FlowName == "" {
+		return nil, errors.NewValidationError("flow.flow_id", "flow ID or flow name is required")
+	}
+	if flow.FlowCTA == "" {
+		return nil, errors.NewValidationError("flow.flow_cta", "flow CTA is required")
+	}
+	if len([]rune(flow.FlowCTA)) > 20 {
+		return nil, errors.NewValidationError("flow.flow_cta", "flow CTA must be 20 characters or less")
+	}
+	if flow.FlowActionPayload != nil && flow.FlowAction == models.FlowActionDataExchange {
+		return nil, errors.NewValidationError("flow.flow_action_payload", "flow action payload is only allowed with the navigate action")
+	}
+	if flow.FlowAction == models.FlowActionNavigate && (flow.FlowActionPayload == nil || flow.FlowActionPayload.Screen == "") {
+		return nil, errors.NewValidationError("flow.flow_action_payload", "screen is required for the navigate action")
+	}
+
+	params := *flow
+	if params.FlowMessageVersion == "" {
+		params.FlowMessageVersion = models.FlowMessageVersion
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeFlow,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name:       "flow",
+			Parameters: &params,
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendProduct sends a single-product message. The body is optional and a
+// header is not allowed.
+func (c *Client) SendProduct(ctx context.Context, to, bodyText, catalogID, productRetailerID string, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if catalogID == "" {
+		return nil, errors.NewValidationError("catalogID", "catalog ID is required")
+	}
+	if productRetailerID == "" {
+		return nil, errors.NewValidationError("productRetailerID", "product retailer ID is required")
+	}
+	if opts != nil && opts.Header != nil {
+		return nil, errors.NewValidationError("header", "single-product messages do not support a header")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeProduct,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			CatalogID:         catalogID,
+			ProductRetailerID: productRetailerID,
+		},
+	}
+
+	if opts != nil && opts.Footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendProductList sends a multi-product message with up to 10 sections and
+// 30 products in total. A text header and a body are required.
+func (c *Client) SendProductList(ctx context.Context, to, headerText, bodyText, catalogID string, sections []models.InteractiveSection, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if headerText == "" {
+		return nil, errors.NewValidationError("headerText", "header text is required")
+	}
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+	if catalogID == "" {
+		return nil, errors.NewValidationError("catalogID", "catalog ID is required")
+	}
+	if err := validateProductSections(sections); err != nil {
+		return nil, err
+	}
+
+	interactive := &models.InteractiveContent{
+		Type:   models.InteractiveTypeProductList,
+		Header: &models.InteractiveHeader{Type: "text", Text: headerText},
+		Body:   models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			CatalogID: catalogID,
+			Sections:  sections,
+		},
+	}
+
+	if opts != nil && opts.Footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendCatalog sends a catalog message that opens the business catalog.
+// thumbnailProductRetailerID optionally selects the product shown as the
+// thumbnail; the first catalog item is used otherwise.
+func (c *Client) SendCatalog(ctx context.Context, to, bodyText, thumbnailProductRetailerID string, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeCatalog,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name: "catalog_message",
+		},
+	}
+	if thumbnailProductRetailerID != "" {
+		interactive.Action.Parameters = &models.ActionParameters{
+			ThumbnailProductRetailerID: thumbnailProductRetailerID,
+		}
+	}
+
+	if opts != nil {
+		interactive.Header = opts.Header
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendOrderDetails sends an order_details message asking the user to review
+// and pay for an order. The parameters must include the reference ID,
+// currency, total amount and order items; the totals are checked by
+// MessageRequest.Validate before sending.
+func (c *Client) SendOrderDetails(ctx context.Context, to, bodyText string, params *models.ActionParameters, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeOrderDetails,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name:       "review_and_pay",
+			Parameters: params,
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendOrderStatus sends an order_status message updating the status of an
+// order previously sent with SendOrderDetails.
+func (c *Client) SendOrderStatus(ctx context.Context, to, bodyText, referenceID string, status models.OrderStatus, description string, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeOrderStatus,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name: "review_order",
+			Parameters: &models.ActionParameters{
+				ReferenceID: referenceID,
+				Order: &models.OrderDetails{
+					Status:      status,
+					Description: description,
+				},
+			},
+		},
+	}
+
+	if opts != nil && opts.Footer != "" {
+		interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendLocationRequest sends a message with a button asking the user to share
+// their location. The location arrives as a location message replying to
+// this one. Only a body is supported.
+func (c *Client) SendLocationRequest(ctx context.Context, to, bodyText string) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive: &models.InteractiveContent{
+			Type: models.InteractiveTypeLocationRequest,
+			Body: models.InteractiveBody{Text: bodyText},
+			Action: models.InteractiveAction{
+				Name: "send_location",
+			},
+		},
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendAddressRequest sends an address message asking the user for a shipping
+// address. address.Country is required; Values, SavedAddresses and
+// ValidationErrors are optional. The submission arrives as an nfm_reply.
+func (c *Client) SendAddressRequest(ctx context.Context, to, bodyText string, address *models.ActionParameters, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeAddress,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name:       "address_message",
+			Parameters: address,
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendVoiceCallButton sends an interactive message with a button that
+// starts a WhatsApp voice call with the business. ttlMinutes controls how
+// long the button stays active; zero uses the API default.
+func (c *Client) SendVoiceCallButton(ctx context.Context, to, bodyText, displayText string, ttlMinutes int, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+	if displayText == "" {
+		return nil, errors.NewValidationError("displayText", "display text is required")
+	}
+	if ttlMinutes < 0 {
+		return nil, errors.NewValidationError("ttlMinutes", "TTL must not be negative")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeVoiceCall,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name: "voice_call",
+			Parameters: &models.ActionParameters{
+				DisplayText: displayText,
+				TTLMinutes:  ttlMinutes,
+			},
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendCallPermissionRequest asks the user for permission to call them.
+// Media headers are not supported on call permission requests.
+func (c *Client) SendCallPermissionRequest(ctx context.Context, to, bodyText string, opts *InteractiveOptions) (*models.MessageResponse, error) {
+	if bodyText == "" {
+		return nil, errors.NewValidationError("bodyText", "body text is required")
+	}
+
+	interactive := &models.InteractiveContent{
+		Type: models.InteractiveTypeCallPermission,
+		Body: models.InteractiveBody{Text: bodyText},
+		Action: models.InteractiveAction{
+			Name: "call_permission_request",
+		},
+	}
+
+	if opts != nil {
+		if opts.Header != nil {
+			interactive.Header = opts.Header
+		}
+		if opts.Footer != "" {
+			interactive.Footer = &models.InteractiveFooter{Text: opts.Footer}
+		}
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeInteractive,
+		Interactive:      interactive,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// validateProductSections checks the section and product limits of a
+// multi-product message.
+func validateProductSections(sections []models.InteractiveSection) error {
+	if len(sections) == 0 {
+		return errors.NewValidationError("sections", "at least one section is required")
+	}
+	if len(sections) > models.MaxProductListSections {
+		return errors.NewValidationError("sections", fmt.Sprintf("maximum %d sections allowed", models.MaxProductListSections))
+	}
+
+	total := 0
+	for i, section := range sections {
+		if len(sections) > 1 && section.Title == "" {
+			return errors.NewValidationError(fmt.Sprintf("sections[%d].title", i), "section title is required when there are multiple sections")
+		}
+		if len(section.ProductItems) == 0 {
+			return errors.NewValidationError(fmt.Sprintf("sections[%d].product_items", i), "at least one product is required")
+		}
+		for j, item := range section.ProductItems {
+			if item.ProductRetailerID == "" {
+				return errors.NewValidationError(fmt.Sprintf("sections[%d].product_items[%d]", i, j), "product retailer ID is required")
+			}
+		}
+		total += len(section.ProductItems)
+	}
+	if total > models.MaxProductListItems {
+		return errors.NewValidationError("sections", fmt.Sprintf("maximum %d products allowed, got %d", models.MaxProductListItems, total))
+	}
+
+	return nil
+}
+
+// InteractiveOptions contains optional settings for interactive messages.
+type InteractiveOptions struct {
+	Header *models.InteractiveHeader
+	Footer string
+}
+
+// ===============================
+// Template Messages
+// ===============================
+
+// SendTemplate sends a template message.
+func (c *Client) SendTemplate(ctx context.Context, to string, template *models.TemplateContent) (*models.MessageResponse, error) {
+	if template == nil {
+		return nil, errors.NewValidationError("template", "template content is required")
+	}
+	if template.Name == "" {
+		return nil, errors.NewValidationError("template.name", "template name is required")
+	}
+	if template.Language.Code == "" {
+		return nil, errors.NewValidationError("template.language.code", "language code is required")
+	}
+
+	req := models.MessageRequest{
+		MessagingProduct: models.MessagingProduct,
+		RecipientType:    "individual",
+		To:               to,
+		Type:             models.MessageTypeTemplate,
+		Template:         template,
+	}
+
+	return c.sendMessage(ctx, &req)
+}
+
+// SendSimpleTemplate sends a template message without parameters.
+func (c *Client) SendSimpleTemplate(ctx context.Context, to, templateName, languageCode string) (*models.MessageResponse, error) {
+	return c.SendTemplate(ctx, to, &models.TemplateContent{
+		Name: templateName,
+		Language: models.TemplateLanguage{
+			Code: languageCode,
+		},
+	})
+}
+
+// SendOTP sends a one-time code using an AUTHENTICATION template definition.
+// The code fills the body placeholder and the parameter of the template's
+// copy-code, one-tap or zero-tap button.
+func (c *Client) SendOTP(ctx context.Context, to string, template *models.Template, code string) (*models.MessageResponse, error) {
+	if template == nil {
+		return nil, errors.NewValidationError("template", "template definition is required")
+	}
+	if !strings.EqualFold(template.Category, models.TemplateCategoryAuthentication) {
+		return nil, errors.NewValidationError("template.category", "OTP messages require an AUTHENTICATION template")
+	}
+	if code == "" {
+		return nil, errors.NewValidationError("code", "code is required")
+	}
+	if len(code) > templates.MaxOTPCodeLength {
+		return nil, errors.NewValidationError("code", fmt.Sprintf("code must be at most %d characters", templates.MaxOTPCodeLength))
+	}
+
+	content := &models.TemplateContent{
+		Name:     template.Name,
+		Language: models.TemplateLanguage{Code: template.Language},
+		Components: []models.TemplateComponent{
+			{
+				Type:       models.TemplateComponentBody,
+				Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: code}},
+			},
+		},
+	}
+
+	for _, comp := range template.Components {
+		if !strings.EqualFold(comp.Type, models.TemplateDefButtons) {
+			continue
+		}
+		for i, btn := range comp.Buttons {
+			isOTP := strings.EqualFold(btn.Type, models.TemplateButtonOTP)
+			// Older authentication templates use a URL button for copy code
+			isCodeURL := strings.EqualFold(btn.Type, models.TemplateButtonURL) && len(templates.Placeholders(btn.URL)) > 0
+			if isOTP || isCodeURL {
+				content.Components = append(content.Components, models.TemplateComponent{
+					Type:       models.TemplateComponentButton,
+					SubType:    models.TemplateButtonSubTypeURL,
+					Index:      strconv.Itoa(i),
+					Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: code}},
+				})
+			}
+		}
+	}
+
+	return c.SendTemplate(ctx, to, content)
+}
+
+// SendBoundTemplate binds args to a fetched template definition and sends it.
+// Mismatched arguments are reported locally as validation errors.
+func (c *Client) SendBoundTemplate(ctx context.Context, to string, tmpl *models.Template, args templates.Args) (*models.MessageResponse, error) {
+	binder, err := templates.NewBinder(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := binder.Bind(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendTemplate(ctx, to, content)
+}
+
+// ===============================
+// Raw Message Sending
+// ===============================
+
+// SendMessage sends a custom message request.
+func (c *Client) SendMessage(ctx context.Context, req *models.MessageRequest) (*models.MessageResponse, error) {
+	return c.sendMessage(ctx, req)
+}
+
+// sendMessage is the internal method that actually sends the message.
+func (c *Client) sendMessage(ctx context.Context, req *models.MessageRequest) (*models.MessageResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Recipient normalization and media uploads change the request, so work
+	// on a copy and leave the caller's request untouched
+	copied := *req
+	req = &copied
+
+	if c.normalizePhone {
+		to, err := phone.Normalize(req.To, c.phoneRegion)
+		if err != nil {
+			return nil, errors.NewValidationError("to", err.Error())
+		}
+		req.To = to
+	}
+
+	if c.dryRun != nil {
+		return c.previewMessage(req)
+	}
+
+	if err := c.uploadLocalMedia(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := waitReplyNotBefore(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp models.MessageResponse
+	err := c.Post(ctx, c.config.GetMessagesURL(), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.contacts != nil {
+		c.contacts.RecordResponse(&resp)
+	}
+
+	return &resp, nil
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// previewMessage writes a preview of req to the dry-run writer and returns
+// a response as if the message had been sent.
+func (c *Client) previewMessage(req *models.MessageRequest) (*models.MessageResponse, error) {
+	p, err := preview.Message(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := fmt.Fprintf(c.dryRun, "--- %s message to %s ---\n%s\n\n", req.Type, req.To, p.Text()); err != nil {
+		return nil, fmt.Errorf("failed to write preview: %w", err)
+	}
+
+	return &models.MessageResponse{
+		MessagingProduct: models.MessagingProduct,
+		Contacts:         []models.ContactInfo{{Input: req.To, WaID: req.To}},
+		Messages:         []models.MessageInfo{{ID: "dry-run"}},
+	}, nil
+}
+
+func validateMediaContent(media *models.MediaContent) error {
+	if media == nil {
+		return errors.NewValidationError("media", "media content is required")
+	}
+	if media.ID == "" && media.Link == "" && media.Path == "" {
+		return errors.NewValidationError("media", "either ID, Link or Path is required")
+	}
+	return nil
+}
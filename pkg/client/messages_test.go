@@ -0,0 +1,36 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+func TestDryRunWritesPreview(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, `{"error":{"message":"unexpected request","code":100}}`, http.StatusBadRequest)
+	}))
+	var out bytes.Buffer
+	WithDryRun(&out)(c)
+
+	resp, err := c.SendImage(context.Background(), "15551234567", &models.MediaContent{Path: "photo.jpg", Caption: "Our shop"})
+	if err != nil {
+		t.Fatalf("SendImage: %v", err)
+	}
+	if len(resp.Messages) != 1 || resp.Messages[0].ID == "" {
+		t.Fatalf("response = %+v, want one message with an ID", resp)
+	}
+
+	want := "--- image message to 15551234567 ---\n[image: photo.jpg]\n\nOur shop\n\n"
+	if out.String() != want {
+		t.Fatalf("preview =\n%q\nwant\n%q", out.String(), want)
+	}
+	if requests != 0 {
+		t.Fatalf("%d HTTP requests in dry run, want 0", requests)
+	}
+}
@@ -0,0 +1,144 @@
+// Package client provides cursor-based pagination for Graph API list endpoints.
+package client
+
+import (
+	"context"
+	neturl "net/url"
+	"strconv"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// ListOptions contains common settings for list endpoints.
+type ListOptions struct {
+	// PageSize is the number of items requested per page (API default if zero)
+	PageSize int
+}
+
+// apply adds the list options to the query parameters.
+func (o *ListOptions) apply(values neturl.Values) {
+	if o != nil && o.PageSize > 0 {
+		values.Set("limit", strconv.Itoa(o.PageSize))
+	}
+}
+
+// page is the common envelope of Graph API list responses.
+type page[T any] struct {
+	Data   []T            `json:"data"`
+	Paging *models.Paging `json:"paging,omitempty"`
+}
+
+// Iterator iterates over the items of a paginated Graph API list endpoint,
+// following the after cursor until the last page.
+//
+//	it := waClient.ListTemplates(ctx, &client.TemplateFilter{Status: "APPROVED"})
+//	for it.Next() {
+//	    template := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx     context.Context
+	client  *Client
+	baseURL string
+	query   neturl.Values
+	nextURL string
+	items   []T
+	index   int
+	current *T
+	err     error
+}
+
+// newIterator creates an iterator starting at the first page of url.
+func newIterator[T any](ctx context.Context, c *Client, url string, query neturl.Values) *Iterator[T] {
+	if query == nil {
+		query = neturl.Values{}
+	}
+
+	it := &Iterator[T]{
+		ctx:     ctx,
+		client:  c,
+		baseURL: url,
+		query:   query,
+		nextURL: url,
+	}
+	if q := query.Encode(); q != "" {
+		it.nextURL += "?" + q
+	}
+
+	return it
+}
+
+// errIterator returns an iterator that yields nothing and reports err.
+func errIterator[T any](err error) *Iterator[T] {
+	return &Iterator[T]{err: err}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.nextURL == "" {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		var resp page[T]
+		if err := it.client.Get(it.ctx, it.nextURL, &resp); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = resp.Data
+		it.index = 0
+		it.nextURL = it.followingURL(resp.Paging)
+	}
+
+	it.current = &it.items[it.index]
+	it.index++
+	return true
+}
+
+// followingURL returns the URL of the page after the current one, or an
+// empty string on the last page. The API omits next on the last page.
+func (it *Iterator[T]) followingURL(paging *models.Paging) string {
+	if paging == nil || paging.Next == "" {
+		return ""
+	}
+	if paging.Cursors.After == "" {
+		return paging.Next
+	}
+
+	query := neturl.Values{}
+	for k, v := range it.query {
+		query[k] = v
+	}
+	query.Set("after", paging.Cursors.After)
+
+	return it.baseURL + "?" + query.Encode()
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() *T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns every remaining item.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, *it.Value())
+	}
+	return items, it.Err()
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// pagedServer serves three pages of templates and records the query of
+// every request. With useNext, pages link to the next one only through
+// paging.next, without an after cursor.
+type pagedServer struct {
+	mu      sync.Mutex
+	url     string
+	useNext bool
+	queries []string
+}
+
+func (s *pagedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.queries = append(s.queries, r.URL.RawQuery)
+	s.mu.Unlock()
+
+	page := 0
+	switch {
+	case r.URL.Query().Get("after") == "c1", r.URL.Query().Get("page") == "1":
+		page = 1
+	case r.URL.Query().Get("after") == "c2", r.URL.Query().Get("page") == "2":
+		page = 2
+	}
+
+	paging := `"cursors":{"before":"b","after":"c%d"},"next":"%s"`
+	next := ""
+	if page < 2 {
+		next = fmt.Sprintf("%s%s?page=%d", s.url, r.URL.Path, page+1)
+	}
+	if s.useNext {
+		paging = `"cursors":{},"next":"%[3]s"`
+	}
+	fmt.Fprintf(w, `{"data":[{"name":"t%d"}],"paging":{`+paging+`}}`, page, page+1, next)
+}
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		useNext     bool
+		filter      *TemplateFilter
+		wantQueries []string
+	}{
+		{"follows after cursor", false, &TemplateFilter{Status: "APPROVED"},
+			[]string{"status=APPROVED", "after=c1&status=APPROVED", "after=c2&status=APPROVED"}},
+		{"follows next URL", true, nil,
+			[]string{"", "page=1", "page=2"}},
+		{"page size", false, &TemplateFilter{ListOptions: ListOptions{PageSize: 1}},
+			[]string{"limit=1", "after=c1&limit=1", "after=c2&limit=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &pagedServer{useNext: tt.useNext}
+			c := newTestClient(t, srv)
+			srv.url = c.config.BaseURL
+
+			templates, err := c.ListTemplates(context.Background(), tt.filter).All()
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			var names []string
+			for _, template := range templates {
+				names = append(names, template.Name)
+			}
+			if want := []string{"t0", "t1", "t2"}; !reflect.DeepEqual(names, want) {
+				t.Fatalf("templates = %v, want %v", names, want)
+			}
+			if !reflect.DeepEqual(srv.queries, tt.wantQueries) {
+				t.Fatalf("queries = %q, want %q", srv.queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+func TestIteratorStopsOnCanceledContext(t *testing.T) {
+	srv := &pagedServer{}
+	c := newTestClient(t, srv)
+	srv.url = c.config.BaseURL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.ListTemplates(ctx, nil)
+	if !it.Next() {
+		t.Fatalf("Next on first page = false: %v", it.Err())
+	}
+	cancel()
+
+	if it.Next() {
+		t.Fatal("Next after cancel = true, want false")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Fatalf("Err = %v, want context.Canceled", it.Err())
+	}
+	if len(srv.queries) != 1 {
+		t.Fatalf("%d requests, want 1", len(srv.queries))
+	}
+}
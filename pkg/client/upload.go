@@ -0,0 +1,291 @@
+// Package client provides resumable upload operations for the WhatsApp API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// Resumable upload defaults
+const (
+	DefaultUploadChunkSize  = 4 * 1024 * 1024 // 4 MB
+	DefaultUploadMaxRetries = 3
+)
+
+// UploadOptions contains optional settings for resumable uploads.
+type UploadOptions struct {
+	// ChunkSize is the number of bytes sent per request (default 4 MB)
+	ChunkSize int64
+
+	// MaxRetries is how many times a failed chunk is resumed before giving up (default 3)
+	MaxRetries int
+}
+
+// ===============================
+// Upload Sessions
+// ===============================
+
+// CreateUploadSession starts a resumable upload session for a file.
+// The returned session ID is used to upload the file data and to resume
+// the upload after an interruption.
+func (c *Client) CreateUploadSession(ctx context.Context, fileName string, fileLength int64, fileType string) (*models.UploadSession, error) {
+	if c.config.AppID == "" {
+		return nil, fmt.Errorf("AppID is required for this operation")
+	}
+	if fileLength <= 0 {
+		return nil, errors.NewValidationError("fileLength", "file length must be greater than zero")
+	}
+	if fileType == "" {
+		return nil, errors.NewValidationError("fileType", "file type is required")
+	}
+
+	query := url.Values{}
+	query.Set("file_length", strconv.FormatInt(fileLength, 10))
+	query.Set("file_type", fileType)
+	if fileName != "" {
+		query.Set("file_name", fileName)
+	}
+
+	endpoint := c.config.GetUploadsURL() + "?" + query.Encode()
+
+	var session models.UploadSession
+	if err := c.Post(ctx, endpoint, nil, &session); err != nil {
+		return nil, err
+	}
+
+	if session.ID == "" {
+		return nil, fmt.Errorf("no upload session ID returned")
+	}
+
+	return &session, nil
+}
+
+// GetUploadSession retrieves the status of an upload session.
+// FileOffset reports how many bytes the server has received so far, and
+// Handle is set once it has received the whole file.
+func (c *Client) GetUploadSession(ctx context.Context, sessionID string) (*models.UploadSession, error) {
+	if sessionID == "" {
+		return nil, errors.NewValidationError("sessionID", "upload session ID is required")
+	}
+
+	var session models.UploadSession
+	if err := c.doUploadRequest(ctx, http.MethodGet, sessionID, 0, nil, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// UploadChunk uploads data to an upload session starting at the given offset.
+// The returned handle is set once the server has received the whole file.
+func (c *Client) UploadChunk(ctx context.Context, sessionID string, offset int64, data []byte) (*models.UploadHandleResponse, error) {
+	if sessionID == "" {
+		return nil, errors.NewValidationError("sessionID", "upload session ID is required")
+	}
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("data", "chunk data is required")
+	}
+	if offset < 0 {
+		return nil, errors.NewValidationError("offset", "offset must not be negative")
+	}
+
+	var result models.UploadHandleResponse
+	if err := c.doUploadRequest(ctx, http.MethodPost, sessionID, offset, data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ResumeUpload continues an interrupted upload. It asks the server how many
+// bytes it already has and uploads the rest of the file from reader. If the
+// server already has the whole file, e.g. because the connection dropped
+// after the last chunk was accepted, the handle is taken from the session
+// status.
+func (c *Client) ResumeUpload(ctx context.Context, sessionID string, reader io.ReaderAt, size int64, opts *UploadOptions) (string, error) {
+	session, err := c.GetUploadSession(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	if session.FileOffset >= size {
+		return sessionHandle(sessionID, session)
+	}
+
+	return c.uploadFrom(ctx, sessionID, reader, size, session.FileOffset, opts)
+}
+
+// ===============================
+// Template Media
+// ===============================
+
+// UploadTemplateMedia uploads a local file through a resumable upload session
+// and returns the handle to use in TemplateExample.HeaderHandle.
+func (c *Client) UploadTemplateMedia(ctx context.Context, filePath string, opts *UploadOptions) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	ext := filepath.Ext(filePath)
+	mimeType := detectMIMEType(ext)
+	if mimeType == "" {
+		return "", errors.NewValidationError("file", fmt.Sprintf("unsupported file type: %s", ext))
+	}
+
+	return c.UploadTemplateMediaReader(ctx, file, fileInfo.Size(), fileInfo.Name(), mimeType, opts)
+}
+
+// UploadTemplateMediaReader uploads media from an io.ReaderAt through a
+// resumable upload session and returns the header handle.
+func (c *Client) UploadTemplateMediaReader(ctx context.Context, reader io.ReaderAt, size int64, filename, mimeType string, opts *UploadOptions) (string, error) {
+	if reader == nil {
+		return "", errors.NewValidationError("reader", "reader is required")
+	}
+
+	session, err := c.CreateUploadSession(ctx, filename, size, mimeType)
+	if err != nil {
+		return "", err
+	}
+
+	return c.uploadFrom(ctx, session.ID, reader, size, 0, opts)
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// uploadFrom uploads reader in chunks starting at offset. When a chunk fails
+// the session offset is queried again and the upload resumes from there.
+func (c *Client) uploadFrom(ctx context.Context, sessionID string, reader io.ReaderAt, size, offset int64, opts *UploadOptions) (string, error) {
+	chunkSize := int64(DefaultUploadChunkSize)
+	maxRetries := DefaultUploadMaxRetries
+	if opts != nil {
+		if opts.ChunkSize > 0 {
+			chunkSize = opts.ChunkSize
+		}
+		if opts.MaxRetries > 0 {
+			maxRetries = opts.MaxRetries
+		}
+	}
+
+	retries := 0
+	for offset < size {
+		n := chunkSize
+		if remaining := size - offset; remaining < n {
+			n = remaining
+		}
+
+		chunk := make([]byte, n)
+		read, err := reader.ReadAt(chunk, offset)
+		if err != nil && !(err == io.EOF && int64(read) == n) {
+			return "", fmt.Errorf("failed to read file at offset %d: %w", offset, err)
+		}
+
+		result, err := c.UploadChunk(ctx, sessionID, offset, chunk)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			// Client errors won't succeed on retry
+			if apiErr, ok := err.(*errors.APIError); ok && apiErr.HTTPStatusCode < 500 {
+				return "", err
+			}
+			retries++
+			if retries > maxRetries {
+				return "", fmt.Errorf("upload interrupted at offset %d: %w", offset, err)
+			}
+
+			session, serr := c.GetUploadSession(ctx, sessionID)
+			if serr != nil {
+				return "", fmt.Errorf("failed to resume upload at offset %d: %w", offset, serr)
+			}
+			if session.FileOffset >= size {
+				return sessionHandle(sessionID, session)
+			}
+			offset = session.FileOffset
+			continue
+		}
+
+		retries = 0
+		offset += n
+
+		if offset >= size {
+			if result.Handle == "" {
+				return "", fmt.Errorf("upload completed without a file handle")
+			}
+			return result.Handle, nil
+		}
+	}
+
+	// Only reached for an empty file, which no session accepts
+	return "", fmt.Errorf("nothing to upload to session %s", sessionID)
+}
+
+// sessionHandle returns the handle of an upload session that has received
+// the whole file, recovering it when the response to the last chunk was lost.
+func sessionHandle(sessionID string, session *models.UploadSession) (string, error) {
+	if session.Handle == "" {
+		return "", fmt.Errorf("upload session %s is complete but returned no file handle", sessionID)
+	}
+	return session.Handle, nil
+}
+
+// doUploadRequest performs a request against an upload session.
+// Upload sessions use OAuth authorization and a raw binary body.
+func (c *Client) doUploadRequest(ctx context.Context, method, sessionID string, offset int64, data []byte, result interface{}) error {
+	endpoint := c.config.GetAPIURL() + "/" + sessionID
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "OAuth "+c.config.AccessToken)
+	if data != nil {
+		// Set directly to keep the header name exactly as the API documents it
+		req.Header["file_offset"] = []string{strconv.FormatInt(offset, 10)}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return c.parseError(respBody, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/config"
+)
+
+// newTestClient returns a client whose API requests go to handler.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := config.DefaultConfig()
+	cfg.BaseURL = srv.URL
+	cfg.PhoneNumberID = "phone"
+	cfg.BusinessAccountID = "waba"
+	cfg.AccessToken = "token"
+	cfg.AppID = "app"
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+// uploadServer is a fake resumable upload endpoint. The first chunk sent
+// at failAt fails with a server error; the first chunk sent at loseAt is
+// stored but answered with a server error, as if the response was lost.
+type uploadServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	size    int64
+	data    []byte
+	offsets []int64
+	failAt  int64
+	loseAt  int64
+	failed  bool
+}
+
+func (s *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/v18.0/app/uploads" {
+		size, _ := strconv.ParseInt(r.URL.Query().Get("file_length"), 10, 64)
+		s.size = size
+		json.NewEncoder(w).Encode(map[string]string{"id": "upload:1"})
+		return
+	}
+	if r.URL.Path != "/v18.0/upload:1" {
+		http.NotFound(w, r)
+		return
+	}
+	if got := r.Header.Get("Authorization"); got != "OAuth token" {
+		s.t.Errorf("Authorization = %q, want OAuth token", got)
+	}
+
+	status := map[string]interface{}{"id": "upload:1", "file_offset": len(s.data)}
+	if int64(len(s.data)) == s.size {
+		status["h"] = "handle"
+	}
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(status)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("file_offset"), 10, 64)
+	if err != nil {
+		s.t.Errorf("file_offset header: %v", err)
+	}
+	s.offsets = append(s.offsets, offset)
+	unavailable := func() {
+		s.failed = true
+		http.Error(w, `{"error":{"message":"temporarily unavailable","code":2}}`, http.StatusServiceUnavailable)
+	}
+	if offset == s.failAt && !s.failed {
+		unavailable()
+		return
+	}
+	if offset != int64(len(s.data)) {
+		http.Error(w, `{"error":{"message":"wrong offset","code":100}}`, http.StatusBadRequest)
+		return
+	}
+
+	chunk := new(bytes.Buffer)
+	chunk.ReadFrom(r.Body)
+	s.data = append(s.data, chunk.Bytes()...)
+	if offset == s.loseAt && !s.failed {
+		unavailable()
+		return
+	}
+
+	result := map[string]string{}
+	if int64(len(s.data)) == s.size {
+		result["h"] = "handle"
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func TestUploadTemplateMediaReader(t *testing.T) {
+	file := []byte("0123456789")
+
+	tests := []struct {
+		name        string
+		failAt      int64
+		loseAt      int64
+		wantOffsets []int64
+	}{
+		{"chunks", -1, -1, []int64{0, 4, 8}},
+		{"retries failed chunk", 4, -1, []int64{0, 4, 4, 8}},
+		{"resumes after lost chunk response", -1, 4, []int64{0, 4, 8}},
+		{"recovers handle after lost last response", -1, 8, []int64{0, 4, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &uploadServer{t: t, failAt: tt.failAt, loseAt: tt.loseAt}
+			c := newTestClient(t, srv)
+
+			handle, err := c.UploadTemplateMediaReader(context.Background(), bytes.NewReader(file), int64(len(file)), "file.txt", "text/plain", &UploadOptions{ChunkSize: 4})
+			if err != nil {
+				t.Fatalf("UploadTemplateMediaReader: %v", err)
+			}
+			if handle != "handle" {
+				t.Fatalf("handle = %q, want handle", handle)
+			}
+			if !bytes.Equal(srv.data, file) {
+				t.Fatalf("server received %q, want %q", srv.data, file)
+			}
+			if !reflect.DeepEqual(srv.offsets, tt.wantOffsets) {
+				t.Fatalf("chunk offsets = %v, want %v", srv.offsets, tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestResumeUpload(t *testing.T) {
+	file := []byte("0123456789")
+
+	tests := []struct {
+		name        string
+		received    int
+		wantOffsets []int64
+	}{
+		{"from server offset", 6, []int64{6}},
+		{"after last chunk", 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &uploadServer{t: t, failAt: -1, loseAt: -1, size: int64(len(file)), data: append([]byte(nil), file[:tt.received]...)}
+			c := newTestClient(t, srv)
+
+			handle, err := c.ResumeUpload(context.Background(), "upload:1", bytes.NewReader(file), int64(len(file)), &UploadOptions{ChunkSize: 4})
+			if err != nil {
+				t.Fatalf("ResumeUpload: %v", err)
+			}
+			if handle != "handle" {
+				t.Fatalf("handle = %q, want handle", handle)
+			}
+			if !bytes.Equal(srv.data, file) {
+				t.Fatalf("server received %q, want %q", srv.data, file)
+			}
+			if !reflect.DeepEqual(srv.offsets, tt.wantOffsets) {
+				t.Fatalf("chunk offsets = %v, want %v", srv.offsets, tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestUploadDoesNotRetryClientErrors(t *testing.T) {
+	file := []byte("0123456789")
+	srv := &uploadServer{t: t, failAt: -1, loseAt: -1, size: int64(len(file)), data: []byte("01")}
+	c := newTestClient(t, srv)
+
+	// Offset 0 conflicts with the two bytes the server already has
+	_, err := c.uploadFrom(context.Background(), "upload:1", bytes.NewReader(file), int64(len(file)), 0, &UploadOptions{ChunkSize: 4})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(srv.offsets) != 1 {
+		t.Fatalf("chunk offsets = %v, want a single attempt", srv.offsets)
+	}
+}
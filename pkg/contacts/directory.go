@@ -0,0 +1,254 @@
+// Package contacts provides a directory that resolves between the phone
+// numbers messages are sent to and the WhatsApp IDs (wa_id) that webhooks
+// are keyed by. The two can differ, e.g. for Brazilian and Mexican mobile
+// numbers, and a wa_id changes when a customer changes their number.
+package contacts
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// Contact is a WhatsApp user known to the directory.
+type Contact struct {
+	WaID          string    // Current WhatsApp ID
+	ProfileName   string    // Profile name from the latest webhook
+	Inputs        []string  // Numbers messages were sent to that resolved to WaID
+	PreviousWaIDs []string  // WhatsApp IDs the user had before changing number
+	UpdatedAt     time.Time // Last time the contact was learned or updated
+}
+
+// Directory maps input numbers and wa_ids to contacts. It is safe for
+// concurrent use.
+type Directory struct {
+	mu       sync.RWMutex
+	contacts map[string]*Contact // by current wa_id
+	aliases  map[string]string   // input number or previous wa_id -> current wa_id
+}
+
+// NewDirectory creates an empty contact directory.
+func NewDirectory() *Directory {
+	return &Directory{
+		contacts: make(map[string]*Contact),
+		aliases:  make(map[string]string),
+	}
+}
+
+// ===============================
+// Learning
+// ===============================
+
+// Learn records that messages sent to input are delivered to waID.
+func (d *Directory) Learn(input, waID string) {
+	waID = digits(waID)
+	if waID == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c := d.contact(waID)
+	if input != "" {
+		if key := digits(input); key != "" && key != c.WaID {
+			d.aliases[key] = c.WaID
+		}
+		if !contains(c.Inputs, input) {
+			c.Inputs = append(c.Inputs, input)
+		}
+	}
+	c.UpdatedAt = time.Now()
+}
+
+// SetProfileName records the profile name of waID.
+func (d *Directory) SetProfileName(waID, name string) {
+	waID = digits(waID)
+	if waID == "" || name == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c := d.contact(waID)
+	c.ProfileName = name
+	c.UpdatedAt = time.Now()
+}
+
+// ChangeNumber moves the contact known as oldWaID to newWaID. The old ID
+// and the contact's inputs keep resolving to the new ID. Webhooks are
+// delivered at least once, so a change whose old ID has already been moved
+// is ignored.
+func (d *Directory) ChangeNumber(oldWaID, newWaID string) {
+	oldWaID, newWaID = digits(oldWaID), digits(newWaID)
+	if oldWaID == "" || newWaID == "" || oldWaID == newWaID {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, ok := d.contacts[oldWaID]
+	if !ok {
+		if _, moved := d.aliases[oldWaID]; moved {
+			return
+		}
+		old = &Contact{WaID: oldWaID}
+	}
+	delete(d.contacts, old.WaID)
+	delete(d.aliases, newWaID)
+
+	c := d.contact(newWaID)
+	for _, input := range old.Inputs {
+		if !contains(c.Inputs, input) {
+			c.Inputs = append(c.Inputs, input)
+		}
+	}
+	for _, id := range append(old.PreviousWaIDs, old.WaID) {
+		if !contains(c.PreviousWaIDs, id) {
+			c.PreviousWaIDs = append(c.PreviousWaIDs, id)
+		}
+	}
+	if c.ProfileName == "" {
+		c.ProfileName = old.ProfileName
+	}
+	c.UpdatedAt = time.Now()
+
+	for key, waID := range d.aliases {
+		if waID == old.WaID {
+			d.aliases[key] = c.WaID
+		}
+	}
+	d.aliases[old.WaID] = c.WaID
+}
+
+// RecordResponse learns the input to wa_id mappings of a send response.
+func (d *Directory) RecordResponse(resp *models.MessageResponse) {
+	if resp == nil {
+		return
+	}
+	for _, contact := range resp.Contacts {
+		d.Learn(contact.Input, contact.WaID)
+	}
+}
+
+// RecordWebhook learns profile names and number changes from a webhook.
+func (d *Directory) RecordWebhook(payload *models.WebhookPayload) {
+	if payload == nil {
+		return
+	}
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			for _, contact := range change.Value.Contacts {
+				d.SetProfileName(contact.WaID, contact.Profile.Name)
+			}
+			for _, msg := range change.Value.Messages {
+				if msg.System != nil && msg.System.Type == models.SystemCustomerChangedNumber {
+					d.ChangeNumber(msg.From, msg.System.NewWaID)
+				}
+			}
+		}
+	}
+}
+
+// ===============================
+// Lookup
+// ===============================
+
+// Lookup returns the contact identified by a wa_id, a previous wa_id or a
+// number messages were sent to. Formatting such as "+" and spaces is ignored.
+func (d *Directory) Lookup(id string) (Contact, bool) {
+	key := digits(id)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	c, ok := d.contacts[key]
+	if !ok {
+		if waID, found := d.aliases[key]; found {
+			c, ok = d.contacts[waID]
+		}
+	}
+	if !ok {
+		return Contact{}, false
+	}
+
+	return c.clone(), true
+}
+
+// WaID resolves id to the current wa_id. It returns id unchanged if the
+// directory does not know it.
+func (d *Directory) WaID(id string) string {
+	if c, ok := d.Lookup(id); ok {
+		return c.WaID
+	}
+	return id
+}
+
+// Contacts returns all known contacts sorted by wa_id.
+func (d *Directory) Contacts() []Contact {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	contacts := make([]Contact, 0, len(d.contacts))
+	for _, c := range d.contacts {
+		contacts = append(contacts, c.clone())
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		return contacts[i].WaID < contacts[j].WaID
+	})
+
+	return contacts
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// contact returns the contact for waID, following number changes and
+// creating it if needed. d.mu must be held for writing.
+func (d *Directory) contact(waID string) *Contact {
+	if current, ok := d.aliases[waID]; ok {
+		if _, known := d.contacts[waID]; !known {
+			waID = current
+		}
+	}
+	c, ok := d.contacts[waID]
+	if !ok {
+		c = &Contact{WaID: waID}
+		d.contacts[waID] = c
+	}
+	return c
+}
+
+// clone returns a copy of c that shares no slices with it.
+func (c *Contact) clone() Contact {
+	out := *c
+	out.Inputs = append([]string(nil), c.Inputs...)
+	out.PreviousWaIDs = append([]string(nil), c.PreviousWaIDs...)
+	return out
+}
+
+// digits strips everything but digits from a phone number or wa_id.
+func digits(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
+
+// contains reports whether values contains v.
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
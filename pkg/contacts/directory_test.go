@@ -0,0 +1,56 @@
+package contacts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeNumber(t *testing.T) {
+	d := NewDirectory()
+	d.Learn("+55 11 0001", "55110001")
+	d.SetProfileName("55110001", "Ana")
+
+	d.ChangeNumber("55110001", "55110002")
+
+	c, ok := d.Lookup("55110001")
+	if !ok {
+		t.Fatal("previous wa_id no longer resolves")
+	}
+	if c.WaID != "55110002" || c.ProfileName != "Ana" {
+		t.Fatalf("contact = %+v, want wa_id 55110002 named Ana", c)
+	}
+	if !reflect.DeepEqual(c.PreviousWaIDs, []string{"55110001"}) {
+		t.Fatalf("PreviousWaIDs = %v, want [55110001]", c.PreviousWaIDs)
+	}
+	if got := d.WaID("+55 11 0001"); got != "55110002" {
+		t.Fatalf("input resolves to %s, want 55110002", got)
+	}
+}
+
+func TestChangeNumberRedelivery(t *testing.T) {
+	d := NewDirectory()
+	d.Learn("", "55110001")
+
+	d.ChangeNumber("55110001", "55110002")
+	d.ChangeNumber("55110001", "55110002") // redelivered
+	d.ChangeNumber("55110002", "55110003")
+	d.ChangeNumber("55110001", "55110002") // late redelivery
+	d.ChangeNumber("55110002", "55110003") // redelivered
+
+	contacts := d.Contacts()
+	if len(contacts) != 1 {
+		t.Fatalf("got %d contacts, want 1: %+v", len(contacts), contacts)
+	}
+	c := contacts[0]
+	if c.WaID != "55110003" {
+		t.Fatalf("WaID = %s, want 55110003", c.WaID)
+	}
+	if want := []string{"55110001", "55110002"}; !reflect.DeepEqual(c.PreviousWaIDs, want) {
+		t.Fatalf("PreviousWaIDs = %v, want %v", c.PreviousWaIDs, want)
+	}
+	for _, id := range []string{"55110001", "55110002", "55110003"} {
+		if got := d.WaID(id); got != "55110003" {
+			t.Fatalf("WaID(%s) = %s, want 55110003", id, got)
+		}
+	}
+}
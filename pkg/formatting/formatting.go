@@ -0,0 +1,133 @@
+// Package formatting provides helpers for WhatsApp's text formatting syntax
+// and conversion of Markdown into it.
+package formatting
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// zeroWidthSpace is inserted after formatting markers in user content so
+// WhatsApp does not treat them as markers.
+const zeroWidthSpace = "\u200b"
+
+// urlPattern matches bare URLs. Trailing punctuation and formatting
+// markers are left out, as they usually belong to the surrounding text.
+var urlPattern = regexp.MustCompile(`(?:https?|ftp)://[^\s<>]*[^\s<>.,;:!?'")\]*_~]`)
+
+// ===============================
+// Inline Styles
+// ===============================
+
+// Bold returns s formatted as bold: *s*.
+func Bold(s string) string {
+	return wrap("*", s)
+}
+
+// Italic returns s formatted as italic: _s_.
+func Italic(s string) string {
+	return wrap("_", s)
+}
+
+// Strikethrough returns s formatted as strikethrough: ~s~.
+func Strikethrough(s string) string {
+	return wrap("~", s)
+}
+
+// Monospace returns s formatted as monospace: ```s```. It may span lines.
+func Monospace(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "```" + s + "```"
+}
+
+// InlineCode returns s formatted as inline code: `s`.
+func InlineCode(s string) string {
+	return wrap("`", s)
+}
+
+// ===============================
+// Blocks
+// ===============================
+
+// Quote prefixes every line of s with "> ".
+func Quote(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// BulletList formats items as a bulleted list.
+func BulletList(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "- " + item
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NumberedList formats items as a numbered list starting at 1.
+func NumberedList(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = strconv.Itoa(i+1) + ". " + item
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ===============================
+// Escaping
+// ===============================
+
+// Escape makes user content safe to embed in formatted text. WhatsApp has
+// no escape character, so a zero width space is inserted after each
+// formatting marker (*, _, ~, `) and after a leading quote or list marker,
+// which keeps them from being interpreted while displaying the same text.
+// URLs are left unchanged so they still open.
+func Escape(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	urls := urlPattern.FindAllStringIndex(s, -1)
+	lineStart := true
+	for i, r := range s {
+		for len(urls) > 0 && i >= urls[0][1] {
+			urls = urls[1:]
+		}
+		if len(urls) > 0 && i >= urls[0][0] {
+			b.WriteRune(r)
+			lineStart = false
+			continue
+		}
+
+		b.WriteRune(r)
+		switch {
+		case r == '*' || r == '_' || r == '~' || r == '`':
+			b.WriteString(zeroWidthSpace)
+		case lineStart && (r == '>' || r == '-'):
+			b.WriteString(zeroWidthSpace)
+		}
+		lineStart = r == '\n' || (lineStart && (r == ' ' || r == '\t'))
+	}
+
+	return b.String()
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// wrap surrounds s with marker. WhatsApp only renders a marker pair when it
+// touches non-space text, so surrounding whitespace is moved outside.
+func wrap(marker, s string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	start := strings.Index(s, trimmed)
+	return s[:start] + marker + trimmed + marker + s[start+len(trimmed):]
+}
@@ -0,0 +1,273 @@
+// Package formatting provides conversion of CommonMark Markdown into
+// WhatsApp's formatting syntax.
+package formatting
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	fencePattern     = regexp.MustCompile("^\\s{0,3}(```+|~~~+)\\s*([\\w+-]*)\\s*$")
+	headingPattern   = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	rulePattern      = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	bulletPattern    = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedPattern   = regexp.MustCompile(`^(\s*)(\d{1,9})[.)]\s+(.*)$`)
+	quotePattern     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	escapePattern    = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	imagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^\s)>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(\s*<?([^\s)>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	autolinkPattern  = regexp.MustCompile(`<((?:https?|mailto):[^\s>]+)>`)
+	strongEmPattern  = regexp.MustCompile(`\*\*\*(\S(?:.*?\S)?)\*\*\*|___(\S(?:.*?\S)?)___`)
+	strongPattern    = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	emPattern        = regexp.MustCompile(`\*(\S(?:.*?\S)??)\*|_(\S(?:.*?\S)??)_`)
+	strikePattern    = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	codeSpanPattern  = regexp.MustCompile("(`+)(.+?)(`+)")
+	urlRefPattern    = regexp.MustCompile("\x02(\\d+)\x02")
+	boldPlaceholder  = "\x01"
+	urlPlaceholder   = "\x02"
+	escapedCharStart = '\uE000' // Private use runes standing in for backslash-escaped ASCII
+)
+
+// FromMarkdown converts CommonMark Markdown into WhatsApp formatting:
+//
+//   - headings become bold lines
+//   - **strong** becomes *bold*, *emphasis* becomes _italic_ and
+//     ~~strikethrough~~ becomes ~strikethrough~
+//   - fenced code blocks become ```monospace``` and `code` stays inline code
+//   - [text](url) links become "text (url)"; images use their alt text
+//   - bulleted and numbered lists, nesting and block quotes are kept
+//   - thematic breaks are dropped
+//
+// Backslash escapes produce the literal character, escaped with Escape if
+// it is a formatting marker.
+func FromMarkdown(md string) string {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	lines := strings.Split(md, "\n")
+	out := make([]string, 0, len(lines))
+
+	var fence string
+	var code []string
+	for _, line := range lines {
+		if fence != "" {
+			if m := fencePattern.FindStringSubmatch(line); m != nil && m[2] == "" && strings.HasPrefix(m[1], fence) {
+				out = append(out, Monospace(strings.Join(code, "\n")))
+				fence, code = "", nil
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			continue
+		}
+
+		switch {
+		case rulePattern.MatchString(line):
+			out = append(out, "")
+		case headingPattern.MatchString(line):
+			text := headingPattern.FindStringSubmatch(line)[1]
+			out = append(out, Bold(finishInline(strings.ReplaceAll(convertInline(text), boldPlaceholder, ""))))
+		case bulletPattern.MatchString(line):
+			m := bulletPattern.FindStringSubmatch(line)
+			out = append(out, m[1]+"- "+finishInline(convertInline(m[2])))
+		case orderedPattern.MatchString(line):
+			m := orderedPattern.FindStringSubmatch(line)
+			out = append(out, m[1]+m[2]+". "+finishInline(convertInline(m[3])))
+		case quotePattern.MatchString(line):
+			text := quotePattern.FindStringSubmatch(line)[1]
+			for quotePattern.MatchString(text) {
+				text = quotePattern.FindStringSubmatch(text)[1]
+			}
+			out = append(out, Quote(finishInline(convertInline(text))))
+		default:
+			out = append(out, finishInline(convertInline(line)))
+		}
+	}
+
+	// An unterminated fence runs to the end of the document
+	if fence != "" {
+		out = append(out, Monospace(strings.Join(code, "\n")))
+	}
+
+	return collapseBlankLines(out)
+}
+
+// convertInline converts the inline Markdown of one line. Bold markers are
+// left as placeholders so they are not mistaken for emphasis.
+func convertInline(text string) string {
+	var b strings.Builder
+
+	// Code spans are copied verbatim
+	last := 0
+	for _, loc := range codeSpanPattern.FindAllStringSubmatchIndex(text, -1) {
+		if text[loc[2]:loc[3]] != text[loc[6]:loc[7]] {
+			continue
+		}
+		b.WriteString(convertSpans(text[last:loc[0]]))
+		b.WriteString(InlineCode(strings.TrimSpace(text[loc[4]:loc[5]])))
+		last = loc[1]
+	}
+	b.WriteString(convertSpans(text[last:]))
+
+	return b.String()
+}
+
+// convertSpans converts links and emphasis in text without code spans.
+// URLs are set aside like code spans, so markers inside them, as in
+// __init__.py, are not taken for emphasis.
+func convertSpans(text string) string {
+	text = escapePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return string(escapedCharStart + rune(m[1]))
+	})
+
+	var urls protectedURLs
+	text = imagePattern.ReplaceAllStringFunc(text, func(m string) string {
+		sub := imagePattern.FindStringSubmatch(m)
+		return linkText(sub[1], sub[2], &urls)
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		sub := linkPattern.FindStringSubmatch(m)
+		return linkText(sub[1], sub[2], &urls)
+	})
+	text = autolinkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return urls.protect(autolinkPattern.FindStringSubmatch(m)[1])
+	})
+	text = urlPattern.ReplaceAllStringFunc(text, urls.protect)
+
+	// ***text*** is bold and italic; converting it as bold first would
+	// leave the markers misnested
+	text = strongEmPattern.ReplaceAllString(text, boldPlaceholder+"_${1}${2}_"+boldPlaceholder)
+	text = strongPattern.ReplaceAllString(text, boldPlaceholder+"${1}${2}"+boldPlaceholder)
+	text = convertEmphasis(text)
+	text = strikePattern.ReplaceAllString(text, "~${1}~")
+
+	return urls.restore(text)
+}
+
+// convertEmphasis converts *emphasis* and _emphasis_ to _italic_. Markers
+// next to a letter or digit, as in 2*3*4 or snake_case_name, are left as
+// they are.
+func convertEmphasis(text string) string {
+	var b strings.Builder
+	pos := 0
+	for pos < len(text) {
+		loc := emPattern.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(before) || isWordRune(after) {
+			// A later marker may still open emphasis
+			b.WriteString(text[pos : start+1])
+			pos = start + 1
+			continue
+		}
+
+		inner := loc[2:4]
+		if inner[0] < 0 {
+			inner = loc[4:6]
+		}
+		b.WriteString(text[pos:start])
+		b.WriteString("_" + text[pos+inner[0]:pos+inner[1]] + "_")
+		pos = end
+	}
+	b.WriteString(text[pos:])
+
+	return b.String()
+}
+
+// isWordRune reports whether r is a letter or digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// finishInline replaces bold placeholders and escaped characters.
+func finishInline(text string) string {
+	text = strings.ReplaceAll(text, boldPlaceholder, "*")
+	return unescapeLiterals(escapeLiterals(text))
+}
+
+// unescapeLiterals replaces the runes standing in for backslash-escaped
+// characters with the characters.
+func unescapeLiterals(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= escapedCharStart && r < escapedCharStart+128 {
+			return r - escapedCharStart
+		}
+		return r
+	}, text)
+}
+
+// escapeLiterals escapes characters that were backslash-escaped in the
+// Markdown and are WhatsApp formatting markers.
+func escapeLiterals(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		b.WriteRune(r)
+		if r >= escapedCharStart && strings.ContainsRune("*_~`", r-escapedCharStart) {
+			b.WriteString(zeroWidthSpace)
+		}
+	}
+	return b.String()
+}
+
+// linkText renders a link as "text (url)", or just the URL if they match.
+// The URL is protected in urls.
+func linkText(text, url string, urls *protectedURLs) string {
+	text = strings.TrimSpace(text)
+	if text == "" || text == url || "mailto:"+text == url {
+		return urls.protect(url)
+	}
+	return text + " (" + urls.protect(url) + ")"
+}
+
+// protectedURLs holds the URLs of a text while the emphasis passes run.
+type protectedURLs []string
+
+// protect stores url and returns the placeholder that stands in for it.
+// Escaped characters in url are stored as the characters themselves.
+func (p *protectedURLs) protect(url string) string {
+	*p = append(*p, unescapeLiterals(url))
+	return urlPlaceholder + strconv.Itoa(len(*p)-1) + urlPlaceholder
+}
+
+// restore replaces the placeholders in text with their URLs.
+func (p protectedURLs) restore(text string) string {
+	if len(p) == 0 {
+		return text
+	}
+	return urlRefPattern.ReplaceAllStringFunc(text, func(m string) string {
+		i, _ := strconv.Atoi(m[1 : len(m)-1])
+		return p[i]
+	})
+}
+
+// collapseBlankLines joins lines, reducing runs of blank lines to one and
+// dropping blank lines at the ends. A code block is a single entry, so the
+// blank lines inside it are kept.
+func collapseBlankLines(lines []string) string {
+	out := make([]string, 0, len(lines))
+	blank := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		out = append(out, line)
+	}
+	if len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,59 @@
+package formatting
+
+import "testing"
+
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"strong and emphasis", "**bold** and *italic* and ~~struck~~", "*bold* and _italic_ and ~struck~"},
+		{"bold italic", "***both*** and ___both___", "*_both_* and *_both_*"},
+		{"nested emphasis", "**bold *italic* text**", "*bold _italic_ text*"},
+		{"bare URL", "See https://example.com/pkg/__init__.py for **details**.", "See https://example.com/pkg/__init__.py for *details*."},
+		{"autolink", "Open <https://example.com/__x__> now", "Open https://example.com/__x__ now"},
+		{"link", "[docs](https://example.com/*a*/_b_)", "docs (https://example.com/*a*/_b_)"},
+		{"URL inside emphasis", "_see https://example.com/a_b_", "_see https://example.com/a_b_"},
+		{"code span", "`__init__` and *em*", "`__init__` and _em_"},
+		{"heading", "# Title", "*Title*"},
+		{"blank lines", "\n\na\n\n\n\nb\n\n", "a\n\nb"},
+		{"blank lines in code", "```go\nfunc f() {\n\n\n\treturn\n}\n```", "```func f() {\n\n\n\treturn\n}```"},
+		{"blank lines around code", "a\n\n\n```\nx\n```\n\n\nb", "a\n\n```x```\n\nb"},
+		{"intraword asterisks", "2*3*4", "2*3*4"},
+		{"multiplication", "5*3 = 15 and x*y", "5*3 = 15 and x*y"},
+		{"intraword underscores", "snake_case_name and _em_", "snake_case_name and _em_"},
+		{"adjacent emphasis", "*a* *b* _c_ _d_", "_a_ _b_ _c_ _d_"},
+		{"emphasis after intraword marker", "a*b and *c*", "a*b and _c_"},
+		{"emphasis in punctuation", "(*a*), \"_b_\"", "(_a_), \"_b_\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromMarkdown(tt.md); got != tt.want {
+				t.Fatalf("FromMarkdown(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"markers", "*hi* _x_", "*\u200bhi*\u200b _\u200bx_\u200b"},
+		{"line start", "- item\n> quote", "-\u200b item\n>\u200b quote"},
+		{"URL", "see https://example.com/__init__.py", "see https://example.com/__init__.py"},
+		{"marker after URL", "https://example.com/a_", "https://example.com/a_\u200b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Escape(tt.in); got != tt.want {
+				t.Fatalf("Escape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
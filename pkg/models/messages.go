@@ -1,698 +1,1380 @@
-// Package models defines all data structures used by the WhatsApp API.
-package models
-
-import "time"
-
-// MessagingProduct is the constant for WhatsApp messaging.
-const MessagingProduct = "whatsapp"
-
-// MessageType represents the type of message.
-type MessageType string
-
-const (
-	MessageTypeText        MessageType = "text"
-	MessageTypeImage       MessageType = "image"
-	MessageTypeAudio       MessageType = "audio"
-	MessageTypeVideo       MessageType = "video"
-	MessageTypeDocument    MessageType = "document"
-	MessageTypeSticker     MessageType = "sticker"
-	MessageTypeLocation    MessageType = "location"
-	MessageTypeContacts    MessageType = "contacts"
-	MessageTypeInteractive MessageType = "interactive"
-	MessageTypeTemplate    MessageType = "template"
-	MessageTypeReaction    MessageType = "reaction"
-)
-
-// MessageStatus represents the delivery status of a message.
-type MessageStatus string
-
-const (
-	StatusSent      MessageStatus = "sent"
-	StatusDelivered MessageStatus = "delivered"
-	StatusRead      MessageStatus = "read"
-	StatusFailed    MessageStatus = "failed"
-)
-
-// ===============================
-// Base Message Structures
-// ===============================
-
-// MessageRequest is the base structure for sending messages.
-type MessageRequest struct {
-	MessagingProduct string      `json:"messaging_product"`
-	RecipientType    string      `json:"recipient_type,omitempty"`
-	To               string      `json:"to"`
-	Type             MessageType `json:"type"`
-	Context          *Context    `json:"context,omitempty"`
-
-	// Message content (only one should be set based on Type)
-	Text        *TextContent        `json:"text,omitempty"`
-	Image       *MediaContent       `json:"image,omitempty"`
-	Audio       *MediaContent       `json:"audio,omitempty"`
-	Video       *MediaContent       `json:"video,omitempty"`
-	Document    *DocumentContent    `json:"document,omitempty"`
-	Sticker     *MediaContent       `json:"sticker,omitempty"`
-	Location    *LocationContent    `json:"location,omitempty"`
-	Contacts    []ContactContent    `json:"contacts,omitempty"`
-	Interactive *InteractiveContent `json:"interactive,omitempty"`
-	Template    *TemplateContent    `json:"template,omitempty"`
-	Reaction    *ReactionContent    `json:"reaction,omitempty"`
-}
-
-// Context is used for replying to a specific message.
-type Context struct {
-	MessageID string `json:"message_id"`
-}
-
-// MessageResponse is the response from sending a message.
-type MessageResponse struct {
-	MessagingProduct string           `json:"messaging_product"`
-	Contacts         []ContactInfo    `json:"contacts"`
-	Messages         []MessageInfo    `json:"messages"`
-	Error            *APIErrorPayload `json:"error,omitempty"`
-}
-
-// ContactInfo contains information about the recipient.
-type ContactInfo struct {
-	Input string `json:"input"`
-	WaID  string `json:"wa_id"`
-}
-
-// MessageInfo contains the message ID.
-type MessageInfo struct {
-	ID            string `json:"id"`
-	MessageStatus string `json:"message_status,omitempty"`
-}
-
-// APIErrorPayload is the error structure in responses.
-type APIErrorPayload struct {
-	Message      string `json:"message"`
-	Type         string `json:"type"`
-	Code         int    `json:"code"`
-	ErrorSubcode int    `json:"error_subcode,omitempty"`
-	FBTraceID    string `json:"fbtrace_id,omitempty"`
-}
-
-// ===============================
-// Text Messages
-// ===============================
-
-// TextContent represents text message content.
-type TextContent struct {
-	Body       string `json:"body"`
-	PreviewURL bool   `json:"preview_url,omitempty"`
-}
-
-// ===============================
-// Media Messages
-// ===============================
-
-// MediaContent represents media message content (image, audio, video, sticker).
-type MediaContent struct {
-	// Use either ID (for uploaded media) or Link (for URL)
-	ID   string `json:"id,omitempty"`
-	Link string `json:"link,omitempty"`
-
-	Caption string `json:"caption,omitempty"` // For image and video only
-}
-
-// DocumentContent represents document message content.
-type DocumentContent struct {
-	ID       string `json:"id,omitempty"`
-	Link     string `json:"link,omitempty"`
-	Caption  string `json:"caption,omitempty"`
-	Filename string `json:"filename,omitempty"`
-}
-
-// MediaUploadResponse is the response from uploading media.
-type MediaUploadResponse struct {
-	ID string `json:"id"`
-}
-
-// MediaURLResponse contains the URL of uploaded media.
-type MediaURLResponse struct {
-	URL           string `json:"url"`
-	MimeType      string `json:"mime_type"`
-	SHA256        string `json:"sha256"`
-	FileSize      int64  `json:"file_size"`
-	ID            string `json:"id"`
-	MessagingProduct string `json:"messaging_product"`
-}
-
-// ===============================
-// Location Messages
-// ===============================
-
-// LocationContent represents location message content.
-type LocationContent struct {
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Name      string  `json:"name,omitempty"`
-	Address   string  `json:"address,omitempty"`
-}
-
-// ===============================
-// Contact Messages
-// ===============================
-
-// ContactContent represents a contact in a contacts message.
-type ContactContent struct {
-	Addresses []ContactAddress `json:"addresses,omitempty"`
-	Birthday  string           `json:"birthday,omitempty"` // YYYY-MM-DD format
-	Emails    []ContactEmail   `json:"emails,omitempty"`
-	Name      ContactName      `json:"name"`
-	Org       *ContactOrg      `json:"org,omitempty"`
-	Phones    []ContactPhone   `json:"phones,omitempty"`
-	URLs      []ContactURL     `json:"urls,omitempty"`
-}
-
-// ContactName represents a contact's name.
-type ContactName struct {
-	FormattedName string `json:"formatted_name"`
-	FirstName     string `json:"first_name,omitempty"`
-	LastName      string `json:"last_name,omitempty"`
-	MiddleName    string `json:"middle_name,omitempty"`
-	Prefix        string `json:"prefix,omitempty"`
-	Suffix        string `json:"suffix,omitempty"`
-}
-
-// ContactAddress represents a contact's address.
-type ContactAddress struct {
-	Type        string `json:"type,omitempty"` // HOME, WORK
-	Street      string `json:"street,omitempty"`
-	City        string `json:"city,omitempty"`
-	State       string `json:"state,omitempty"`
-	Zip         string `json:"zip,omitempty"`
-	Country     string `json:"country,omitempty"`
-	CountryCode string `json:"country_code,omitempty"`
-}
-
-// ContactEmail represents a contact's email.
-type ContactEmail struct {
-	Type  string `json:"type,omitempty"` // HOME, WORK
-	Email string `json:"email"`
-}
-
-// ContactPhone represents a contact's phone.
-type ContactPhone struct {
-	Type  string `json:"type,omitempty"` // CELL, MAIN, IPHONE, HOME, WORK
-	Phone string `json:"phone"`
-	WaID  string `json:"wa_id,omitempty"`
-}
-
-// ContactOrg represents a contact's organization.
-type ContactOrg struct {
-	Company    string `json:"company,omitempty"`
-	Department string `json:"department,omitempty"`
-	Title      string `json:"title,omitempty"`
-}
-
-// ContactURL represents a contact's URL.
-type ContactURL struct {
-	Type string `json:"type,omitempty"` // HOME, WORK
-	URL  string `json:"url"`
-}
-
-// ===============================
-// Reaction Messages
-// ===============================
-
-// ReactionContent represents a reaction to a message.
-type ReactionContent struct {
-	MessageID string `json:"message_id"`
-	Emoji     string `json:"emoji"` // Empty string to remove reaction
-}
-
-// ===============================
-// Interactive Messages
-// ===============================
-
-// InteractiveType represents the type of interactive message.
-type InteractiveType string
-
-const (
-	InteractiveTypeButton     InteractiveType = "button"
-	InteractiveTypeList       InteractiveType = "list"
-	InteractiveTypeProduct    InteractiveType = "product"
-	InteractiveTypeProductList InteractiveType = "product_list"
-	InteractiveTypeFlow       InteractiveType = "flow"
-	InteractiveTypeCTA        InteractiveType = "cta_url"
-)
-
-// InteractiveContent represents interactive message content.
-type InteractiveContent struct {
-	Type   InteractiveType      `json:"type"`
-	Header *InteractiveHeader   `json:"header,omitempty"`
-	Body   InteractiveBody      `json:"body"`
-	Footer *InteractiveFooter   `json:"footer,omitempty"`
-	Action InteractiveAction    `json:"action"`
-}
-
-// InteractiveHeader represents the header of an interactive message.
-type InteractiveHeader struct {
-	Type     string        `json:"type"` // text, image, video, document
-	Text     string        `json:"text,omitempty"`
-	Image    *MediaContent `json:"image,omitempty"`
-	Video    *MediaContent `json:"video,omitempty"`
-	Document *MediaContent `json:"document,omitempty"`
-}
-
-// InteractiveBody represents the body of an interactive message.
-type InteractiveBody struct {
-	Text string `json:"text"`
-}
-
-// InteractiveFooter represents the footer of an interactive message.
-type InteractiveFooter struct {
-	Text string `json:"text"`
-}
-
-// InteractiveAction represents the action of an interactive message.
-type InteractiveAction struct {
-	// For button type
-	Buttons []InteractiveButton `json:"buttons,omitempty"`
-
-	// For list type
-	Button   string               `json:"button,omitempty"` // Button text for list
-	Sections []InteractiveSection `json:"sections,omitempty"`
-
-	// For CTA URL type
-	Name       string `json:"name,omitempty"`        // "cta_url"
-	Parameters *CTAParameters `json:"parameters,omitempty"`
-
-	// For product type
-	CatalogID         string `json:"catalog_id,omitempty"`
-	ProductRetailerID string `json:"product_retailer_id,omitempty"`
-}
-
-// InteractiveButton represents a button in an interactive message.
-type InteractiveButton struct {
-	Type  string             `json:"type"` // "reply"
-	Reply InteractiveReply   `json:"reply"`
-}
-
-// InteractiveReply represents a reply button.
-type InteractiveReply struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
-}
-
-// InteractiveSection represents a section in a list message.
-type InteractiveSection struct {
-	Title string          `json:"title,omitempty"`
-	Rows  []InteractiveRow `json:"rows"`
-}
-
-// InteractiveRow represents a row in a list section.
-type InteractiveRow struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-}
-
-// CTAParameters represents CTA URL parameters.
-type CTAParameters struct {
-	DisplayText string `json:"display_text"`
-	URL         string `json:"url"`
-}
-
-// ===============================
-// Template Messages
-// ===============================
-
-// TemplateContent represents template message content.
-type TemplateContent struct {
-	Name       string              `json:"name"`
-	Language   TemplateLanguage    `json:"language"`
-	Components []TemplateComponent `json:"components,omitempty"`
-}
-
-// TemplateLanguage represents template language settings.
-type TemplateLanguage struct {
-	Code string `json:"code"` // e.g., "en_US", "es", "pt_BR"
-}
-
-// TemplateComponentType represents the type of template component.
-type TemplateComponentType string
-
-const (
-	TemplateComponentHeader TemplateComponentType = "header"
-	TemplateComponentBody   TemplateComponentType = "body"
-	TemplateComponentButton TemplateComponentType = "button"
-)
-
-// TemplateComponent represents a component in a template.
-type TemplateComponent struct {
-	Type       TemplateComponentType `json:"type"`
-	SubType    string                `json:"sub_type,omitempty"` // For buttons: quick_reply, url
-	Index      string                `json:"index,omitempty"`    // For buttons
-	Parameters []TemplateParameter   `json:"parameters,omitempty"`
-}
-
-// TemplateParameterType represents the type of template parameter.
-type TemplateParameterType string
-
-const (
-	TemplateParamText     TemplateParameterType = "text"
-	TemplateParamCurrency TemplateParameterType = "currency"
-	TemplateParamDateTime TemplateParameterType = "date_time"
-	TemplateParamImage    TemplateParameterType = "image"
-	TemplateParamDocument TemplateParameterType = "document"
-	TemplateParamVideo    TemplateParameterType = "video"
-	TemplateParamPayload  TemplateParameterType = "payload"
-)
-
-// TemplateParameter represents a parameter in a template component.
-type TemplateParameter struct {
-	Type     TemplateParameterType `json:"type"`
-	Text     string                `json:"text,omitempty"`
-	Currency *CurrencyParam        `json:"currency,omitempty"`
-	DateTime *DateTimeParam        `json:"date_time,omitempty"`
-	Image    *MediaContent         `json:"image,omitempty"`
-	Document *DocumentContent      `json:"document,omitempty"`
-	Video    *MediaContent         `json:"video,omitempty"`
-	Payload  string                `json:"payload,omitempty"` // For quick reply buttons
-}
-
-// CurrencyParam represents a currency parameter.
-type CurrencyParam struct {
-	FallbackValue string `json:"fallback_value"`
-	Code          string `json:"code"`          // ISO 4217 code
-	Amount1000    int64  `json:"amount_1000"`   // Amount in thousandths
-}
-
-// DateTimeParam represents a date/time parameter.
-type DateTimeParam struct {
-	FallbackValue string `json:"fallback_value"`
-}
-
-// ===============================
-// Business Profile
-// ===============================
-
-// BusinessProfile represents the WhatsApp Business Profile.
-type BusinessProfile struct {
-	MessagingProduct string   `json:"messaging_product,omitempty"`
-	About            string   `json:"about,omitempty"`
-	Address          string   `json:"address,omitempty"`
-	Description      string   `json:"description,omitempty"`
-	Email            string   `json:"email,omitempty"`
-	ProfilePictureURL string  `json:"profile_picture_url,omitempty"`
-	Websites         []string `json:"websites,omitempty"`
-	Vertical         string   `json:"vertical,omitempty"`
-}
-
-// BusinessProfileResponse is the response for business profile requests.
-type BusinessProfileResponse struct {
-	Data []BusinessProfile `json:"data"`
-}
-
-// ===============================
-// Phone Numbers
-// ===============================
-
-// PhoneNumber represents a WhatsApp phone number.
-type PhoneNumber struct {
-	ID                    string `json:"id"`
-	DisplayPhoneNumber    string `json:"display_phone_number"`
-	VerifiedName          string `json:"verified_name"`
-	QualityRating         string `json:"quality_rating"`
-	CodeVerificationStatus string `json:"code_verification_status,omitempty"`
-}
-
-// PhoneNumbersResponse is the response for phone numbers requests.
-type PhoneNumbersResponse struct {
-	Data   []PhoneNumber `json:"data"`
-	Paging *Paging       `json:"paging,omitempty"`
-}
-
-// Paging contains pagination information.
-type Paging struct {
-	Cursors Cursors `json:"cursors"`
-	Next    string  `json:"next,omitempty"`
-}
-
-// Cursors contains cursor information for pagination.
-type Cursors struct {
-	Before string `json:"before"`
-	After  string `json:"after"`
-}
-
-// ===============================
-// Message Templates
-// ===============================
-
-// Template represents a message template.
-type Template struct {
-	ID         string             `json:"id"`
-	Name       string             `json:"name"`
-	Status     string             `json:"status"`
-	Category   string             `json:"category"`
-	Language   string             `json:"language"`
-	Components []TemplateComponentDef `json:"components,omitempty"`
-}
-
-// TemplateComponentDef represents a template component definition.
-type TemplateComponentDef struct {
-	Type    string                 `json:"type"`
-	Format  string                 `json:"format,omitempty"`
-	Text    string                 `json:"text,omitempty"`
-	Buttons []TemplateButtonDef    `json:"buttons,omitempty"`
-	Example *TemplateExample       `json:"example,omitempty"`
-}
-
-// TemplateButtonDef represents a button in a template definition.
-type TemplateButtonDef struct {
-	Type        string `json:"type"`
-	Text        string `json:"text"`
-	URL         string `json:"url,omitempty"`
-	PhoneNumber string `json:"phone_number,omitempty"`
-}
-
-// TemplateExample represents example values for a template.
-type TemplateExample struct {
-	HeaderText  []string   `json:"header_text,omitempty"`
-	BodyText    [][]string `json:"body_text,omitempty"`
-	HeaderHandle []string  `json:"header_handle,omitempty"`
-}
-
-// TemplatesResponse is the response for templates requests.
-type TemplatesResponse struct {
-	Data   []Template `json:"data"`
-	Paging *Paging    `json:"paging,omitempty"`
-}
-
-// ===============================
-// Webhook Events
-// ===============================
-
-// WebhookPayload is the payload received from webhook callbacks.
-type WebhookPayload struct {
-	Object string         `json:"object"`
-	Entry  []WebhookEntry `json:"entry"`
-}
-
-// WebhookEntry represents an entry in the webhook payload.
-type WebhookEntry struct {
-	ID      string          `json:"id"`
-	Changes []WebhookChange `json:"changes"`
-}
-
-// WebhookChange represents a change in the webhook entry.
-type WebhookChange struct {
-	Value WebhookValue `json:"value"`
-	Field string       `json:"field"`
-}
-
-// WebhookValue contains the actual webhook data.
-type WebhookValue struct {
-	MessagingProduct string            `json:"messaging_product"`
-	Metadata         WebhookMetadata   `json:"metadata"`
-	Contacts         []WebhookContact  `json:"contacts,omitempty"`
-	Messages         []IncomingMessage `json:"messages,omitempty"`
-	Statuses         []MessageStatusUpdate `json:"statuses,omitempty"`
-	Errors           []WebhookError    `json:"errors,omitempty"`
-}
-
-// WebhookMetadata contains metadata about the webhook.
-type WebhookMetadata struct {
-	DisplayPhoneNumber string `json:"display_phone_number"`
-	PhoneNumberID      string `json:"phone_number_id"`
-}
-
-// WebhookContact represents a contact in the webhook.
-type WebhookContact struct {
-	WaID    string         `json:"wa_id"`
-	Profile ContactProfile `json:"profile"`
-}
-
-// ContactProfile represents the profile of a contact.
-type ContactProfile struct {
-	Name string `json:"name"`
-}
-
-// IncomingMessage represents an incoming message.
-type IncomingMessage struct {
-	ID        string            `json:"id"`
-	From      string            `json:"from"`
-	Timestamp string            `json:"timestamp"`
-	Type      MessageType       `json:"type"`
-	Context   *MessageContext   `json:"context,omitempty"`
-	Errors    []WebhookError    `json:"errors,omitempty"`
-
-	// Message content based on type
-	Text        *IncomingText        `json:"text,omitempty"`
-	Image       *IncomingMedia       `json:"image,omitempty"`
-	Audio       *IncomingMedia       `json:"audio,omitempty"`
-	Video       *IncomingMedia       `json:"video,omitempty"`
-	Document    *IncomingDocument    `json:"document,omitempty"`
-	Sticker     *IncomingMedia       `json:"sticker,omitempty"`
-	Location    *LocationContent     `json:"location,omitempty"`
-	Contacts    []ContactContent     `json:"contacts,omitempty"`
-	Interactive *IncomingInteractive `json:"interactive,omitempty"`
-	Button      *IncomingButton      `json:"button,omitempty"`
-	Reaction    *IncomingReaction    `json:"reaction,omitempty"`
-	Referral    *Referral            `json:"referral,omitempty"`
-	System      *SystemMessage       `json:"system,omitempty"`
-}
-
-// MessageContext represents the context of a replied message.
-type MessageContext struct {
-	From      string `json:"from"`
-	ID        string `json:"id"`
-	Forwarded bool   `json:"forwarded,omitempty"`
-}
-
-// IncomingText represents incoming text content.
-type IncomingText struct {
-	Body string `json:"body"`
-}
-
-// IncomingMedia represents incoming media content.
-type IncomingMedia struct {
-	ID       string `json:"id"`
-	MimeType string `json:"mime_type"`
-	SHA256   string `json:"sha256"`
-	Caption  string `json:"caption,omitempty"`
-}
-
-// IncomingDocument represents an incoming document.
-type IncomingDocument struct {
-	ID       string `json:"id"`
-	MimeType string `json:"mime_type"`
-	SHA256   string `json:"sha256"`
-	Filename string `json:"filename,omitempty"`
-	Caption  string `json:"caption,omitempty"`
-}
-
-// IncomingInteractive represents an interactive response.
-type IncomingInteractive struct {
-	Type        string               `json:"type"` // button_reply, list_reply
-	ButtonReply *InteractiveReply    `json:"button_reply,omitempty"`
-	ListReply   *InteractiveListReply `json:"list_reply,omitempty"`
-}
-
-// InteractiveListReply represents a list reply.
-type InteractiveListReply struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-}
-
-// IncomingButton represents a button response.
-type IncomingButton struct {
-	Text    string `json:"text"`
-	Payload string `json:"payload"`
-}
-
-// IncomingReaction represents an incoming reaction.
-type IncomingReaction struct {
-	MessageID string `json:"message_id"`
-	Emoji     string `json:"emoji"`
-}
-
-// Referral represents click-to-WhatsApp referral data.
-type Referral struct {
-	SourceURL  string `json:"source_url"`
-	SourceType string `json:"source_type"`
-	SourceID   string `json:"source_id"`
-	Headline   string `json:"headline,omitempty"`
-	Body       string `json:"body,omitempty"`
-	MediaType  string `json:"media_type,omitempty"`
-	ImageURL   string `json:"image_url,omitempty"`
-	VideoURL   string `json:"video_url,omitempty"`
-	ThumbnailURL string `json:"thumbnail_url,omitempty"`
-}
-
-// SystemMessage represents a system-generated message.
-type SystemMessage struct {
-	Type     string `json:"type"` // customer_changed_number, customer_identity_changed
-	Body     string `json:"body"`
-	NewWaID  string `json:"new_wa_id,omitempty"`
-	Identity string `json:"identity,omitempty"`
-}
-
-// MessageStatusUpdate represents a message status update.
-type MessageStatusUpdate struct {
-	ID           string          `json:"id"`
-	Status       MessageStatus   `json:"status"`
-	Timestamp    string          `json:"timestamp"`
-	RecipientID  string          `json:"recipient_id"`
-	Conversation *Conversation   `json:"conversation,omitempty"`
-	Pricing      *Pricing        `json:"pricing,omitempty"`
-	Errors       []WebhookError  `json:"errors,omitempty"`
-}
-
-// Conversation contains conversation information.
-type Conversation struct {
-	ID                  string             `json:"id"`
-	Origin              ConversationOrigin `json:"origin"`
-	ExpirationTimestamp string             `json:"expiration_timestamp,omitempty"`
-}
-
-// ConversationOrigin represents the origin of a conversation.
-type ConversationOrigin struct {
-	Type string `json:"type"` // user_initiated, business_initiated, referral_conversion
-}
-
-// Pricing contains pricing information for a message.
-type Pricing struct {
-	PricingModel string `json:"pricing_model"`
-	Billable     bool   `json:"billable"`
-	Category     string `json:"category"`
-}
-
-// WebhookError represents an error in a webhook.
-type WebhookError struct {
-	Code    int    `json:"code"`
-	Title   string `json:"title"`
-	Message string `json:"message,omitempty"`
-	Details string `json:"error_data,omitempty"`
-}
-
-// ===============================
-// Utility Types
-// ===============================
-
-// Timestamp is a custom time type for JSON parsing.
-type Timestamp time.Time
-
-// UnmarshalJSON parses a Unix timestamp.
-func (t *Timestamp) UnmarshalJSON(b []byte) error {
-	var ts int64
-	if err := json.Unmarshal(b, &ts); err != nil {
-		// Try as string
-		var s string
-		if err := json.Unmarshal(b, &s); err != nil {
-			return err
-		}
-		ts, _ = strconv.ParseInt(s, 10, 64)
-	}
-	*t = Timestamp(time.Unix(ts, 0))
-	return nil
-}
-
-// Time returns the time.Time value.
-func (t Timestamp) Time() time.Time {
-	return time.Time(t)
-}
+// Package models defines all data structures used by the WhatsApp API.
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MessagingProduct is the constant for WhatsApp messaging.
+const MessagingProduct = "whatsapp"
+
+// MessageType represents the type of message.
+type MessageType string
+
+const (
+	MessageTypeText        MessageType = "text"
+	MessageTypeImage       MessageType = "image"
+	MessageTypeAudio       MessageType = "audio"
+	MessageTypeVideo       MessageType = "video"
+	MessageTypeDocument    MessageType = "document"
+	MessageTypeSticker     MessageType = "sticker"
+	MessageTypeLocation    MessageType = "location"
+	MessageTypeContacts    MessageType = "contacts"
+	MessageTypeInteractive MessageType = "interactive"
+	MessageTypeTemplate    MessageType = "template"
+	MessageTypeReaction    MessageType = "reaction"
+	MessageTypeOrder       MessageType = "order"
+	MessageTypeSystem      MessageType = "system"
+)
+
+// MessageStatus represents the delivery status of a message.
+type MessageStatus string
+
+const (
+	StatusSent      MessageStatus = "sent"
+	StatusDelivered MessageStatus = "delivered"
+	StatusRead      MessageStatus = "read"
+	StatusFailed    MessageStatus = "failed"
+)
+
+// ===============================
+// Base Message Structures
+// ===============================
+
+// MessageRequest is the base structure for sending messages.
+type MessageRequest struct {
+	MessagingProduct string      `json:"messaging_product"`
+	RecipientType    string      `json:"recipient_type,omitempty"`
+	To               string      `json:"to"`
+	Type             MessageType `json:"type"`
+	Context          *Context    `json:"context,omitempty"`
+
+	// Message content (only one should be set based on Type)
+	Text        *TextContent        `json:"text,omitempty"`
+	Image       *MediaContent       `json:"image,omitempty"`
+	Audio       *MediaContent       `json:"audio,omitempty"`
+	Video       *MediaContent       `json:"video,omitempty"`
+	Document    *DocumentContent    `json:"document,omitempty"`
+	Sticker     *MediaContent       `json:"sticker,omitempty"`
+	Location    *LocationContent    `json:"location,omitempty"`
+	Contacts    []ContactContent    `json:"contacts,omitempty"`
+	Interactive *InteractiveContent `json:"interactive,omitempty"`
+	Template    *TemplateContent    `json:"template,omitempty"`
+	Reaction    *ReactionContent    `json:"reaction,omitempty"`
+}
+
+// Context is used for replying to a specific message.
+type Context struct {
+	MessageID string `json:"message_id"`
+}
+
+// MessageResponse is the response from sending a message.
+type MessageResponse struct {
+	MessagingProduct string           `json:"messaging_product"`
+	Contacts         []ContactInfo    `json:"contacts"`
+	Messages         []MessageInfo    `json:"messages"`
+	Error            *APIErrorPayload `json:"error,omitempty"`
+}
+
+// ContactInfo contains information about the recipient.
+type ContactInfo struct {
+	Input string `json:"input"`
+	WaID  string `json:"wa_id"`
+}
+
+// MessageInfo contains the message ID.
+type MessageInfo struct {
+	ID            string `json:"id"`
+	MessageStatus string `json:"message_status,omitempty"`
+}
+
+// APIErrorPayload is the error structure in responses.
+type APIErrorPayload struct {
+	Message      string `json:"message"`
+	Type         string `json:"type"`
+	Code         int    `json:"code"`
+	ErrorSubcode int    `json:"error_subcode,omitempty"`
+	FBTraceID    string `json:"fbtrace_id,omitempty"`
+}
+
+// ===============================
+// Text Messages
+// ===============================
+
+// TextContent represents text message content.
+type TextContent struct {
+	Body       string `json:"body"`
+	PreviewURL bool   `json:"preview_url,omitempty"`
+}
+
+// ===============================
+// Media Messages
+// ===============================
+
+// MediaContent represents media message content (image, audio, video, sticker).
+type MediaContent struct {
+	// Use either ID (for uploaded media), Link (for URL) or Path (local file)
+	ID   string `json:"id,omitempty"`
+	Link string `json:"link,omitempty"`
+	Path string `json:"-"` // Uploaded by the client before sending; ID is set afterwards
+
+	Caption string `json:"caption,omitempty"` // For image and video only
+}
+
+// DocumentContent represents document message content.
+type DocumentContent struct {
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Path     string `json:"-"` // Uploaded by the client before sending
+	Caption  string `json:"caption,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// MediaUploadResponse is the response from uploading media.
+type MediaUploadResponse struct {
+	ID string `json:"id"`
+}
+
+// MediaURLResponse contains the URL of uploaded media.
+type MediaURLResponse struct {
+	URL              string `json:"url"`
+	MimeType         string `json:"mime_type"`
+	SHA256           string `json:"sha256"`
+	FileSize         int64  `json:"file_size"`
+	ID               string `json:"id"`
+	MessagingProduct string `json:"messaging_product"`
+}
+
+// ===============================
+// Location Messages
+// ===============================
+
+// LocationContent represents location message content.
+type LocationContent struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// ===============================
+// Contact Messages
+// ===============================
+
+// ContactContent represents a contact in a contacts message.
+type ContactContent struct {
+	Addresses []ContactAddress `json:"addresses,omitempty"`
+	Birthday  string           `json:"birthday,omitempty"` // YYYY-MM-DD format
+	Emails    []ContactEmail   `json:"emails,omitempty"`
+	Name      ContactName      `json:"name"`
+	Org       *ContactOrg      `json:"org,omitempty"`
+	Phones    []ContactPhone   `json:"phones,omitempty"`
+	URLs      []ContactURL     `json:"urls,omitempty"`
+}
+
+// ContactName represents a contact's name.
+type ContactName struct {
+	FormattedName string `json:"formatted_name"`
+	FirstName     string `json:"first_name,omitempty"`
+	LastName      string `json:"last_name,omitempty"`
+	MiddleName    string `json:"middle_name,omitempty"`
+	Prefix        string `json:"prefix,omitempty"`
+	Suffix        string `json:"suffix,omitempty"`
+}
+
+// ContactAddress represents a contact's address.
+type ContactAddress struct {
+	Type        string `json:"type,omitempty"` // HOME, WORK
+	Street      string `json:"street,omitempty"`
+	City        string `json:"city,omitempty"`
+	State       string `json:"state,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+}
+
+// ContactEmail represents a contact's email.
+type ContactEmail struct {
+	Type  string `json:"type,omitempty"` // HOME, WORK
+	Email string `json:"email"`
+}
+
+// ContactPhone represents a contact's phone.
+type ContactPhone struct {
+	Type  string `json:"type,omitempty"` // CELL, MAIN, IPHONE, HOME, WORK
+	Phone string `json:"phone"`
+	WaID  string `json:"wa_id,omitempty"`
+}
+
+// ContactOrg represents a contact's organization.
+type ContactOrg struct {
+	Company    string `json:"company,omitempty"`
+	Department string `json:"department,omitempty"`
+	Title      string `json:"title,omitempty"`
+}
+
+// ContactURL represents a contact's URL.
+type ContactURL struct {
+	Type string `json:"type,omitempty"` // HOME, WORK
+	URL  string `json:"url"`
+}
+
+// ===============================
+// Reaction Messages
+// ===============================
+
+// ReactionContent represents a reaction to a message.
+type ReactionContent struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"` // Empty string to remove reaction
+}
+
+// ===============================
+// Interactive Messages
+// ===============================
+
+// InteractiveType represents the type of interactive message.
+type InteractiveType string
+
+const (
+	InteractiveTypeButton          InteractiveType = "button"
+	InteractiveTypeList            InteractiveType = "list"
+	InteractiveTypeProduct         InteractiveType = "product"
+	InteractiveTypeProductList     InteractiveType = "product_list"
+	InteractiveTypeFlow            InteractiveType = "flow"
+	InteractiveTypeCTA             InteractiveType = "cta_url"
+	InteractiveTypeCatalog         InteractiveType = "catalog_message"
+	InteractiveTypeOrderDetails    InteractiveType = "order_details"
+	InteractiveTypeOrderStatus     InteractiveType = "order_status"
+	InteractiveTypeLocationRequest InteractiveType = "location_request_message"
+	InteractiveTypeAddress         InteractiveType = "address_message"
+	InteractiveTypeVoiceCall       InteractiveType = "voice_call"
+	InteractiveTypeCallPermission  InteractiveType = "call_permission_request"
+)
+
+// Commerce message limits
+const (
+	MaxProductListSections = 10
+	MaxProductListItems    = 30
+)
+
+// InteractiveContent represents interactive message content.
+type InteractiveContent struct {
+	Type   InteractiveType    `json:"type"`
+	Header *InteractiveHeader `json:"header,omitempty"`
+	Body   InteractiveBody    `json:"body"`
+	Footer *InteractiveFooter `json:"footer,omitempty"`
+	Action InteractiveAction  `json:"action"`
+}
+
+// MarshalJSON omits the body when it has no text, since single-product
+// messages allow it to be left out.
+func (c InteractiveContent) MarshalJSON() ([]byte, error) {
+	type content InteractiveContent
+	if c.Body.Text != "" {
+		return json.Marshal(content(c))
+	}
+	return json.Marshal(struct {
+		content
+		Body *InteractiveBody `json:"body,omitempty"`
+	}{content: content(c)})
+}
+
+// InteractiveHeader represents the header of an interactive message.
+type InteractiveHeader struct {
+	Type     string        `json:"type"` // text, image, video, document
+	Text     string        `json:"text,omitempty"`
+	Image    *MediaContent `json:"image,omitempty"`
+	Video    *MediaContent `json:"video,omitempty"`
+	Document *MediaContent `json:"document,omitempty"`
+}
+
+// InteractiveBody represents the body of an interactive message.
+type InteractiveBody struct {
+	Text string `json:"text"`
+}
+
+// InteractiveFooter represents the footer of an interactive message.
+type InteractiveFooter struct {
+	Text string `json:"text"`
+}
+
+// InteractiveAction represents the action of an interactive message.
+type InteractiveAction struct {
+	// For button type
+	Buttons []InteractiveButton `json:"buttons,omitempty"`
+
+	// For list type
+	Button   string               `json:"button,omitempty"` // Button text for list
+	Sections []InteractiveSection `json:"sections,omitempty"`
+
+	// For CTA URL and flow types
+	Name       string            `json:"name,omitempty"` // "cta_url", "flow"
+	Parameters *ActionParameters `json:"parameters,omitempty"`
+
+	// For product and product_list types
+	CatalogID         string `json:"catalog_id,omitempty"`
+	ProductRetailerID string `json:"product_retailer_id,omitempty"`
+}
+
+// InteractiveButton represents a button in an interactive message.
+type InteractiveButton struct {
+	Type  string           `json:"type"` // "reply"
+	Reply InteractiveReply `json:"reply"`
+}
+
+// InteractiveReply represents a reply button.
+type InteractiveReply struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// InteractiveSection represents a section in a list message.
+type InteractiveSection struct {
+	Title        string           `json:"title,omitempty"`
+	Rows         []InteractiveRow `json:"rows,omitempty"`
+	ProductItems []ProductItem    `json:"product_items,omitempty"` // For product_list
+}
+
+// ProductItem references a catalog product in a product list section.
+type ProductItem struct {
+	ProductRetailerID string `json:"product_retailer_id"`
+}
+
+// InteractiveRow represents a row in a list section.
+type InteractiveRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// ActionParameters represents the parameters of a named interactive action.
+// CTA URL messages use DisplayText and URL, flow messages use the Flow
+// fields, catalog messages use ThumbnailProductRetailerID, order messages
+// use the Order fields and address messages use the Address fields.
+type ActionParameters struct {
+	// CTA URL and voice call
+	DisplayText string `json:"display_text,omitempty"`
+	URL         string `json:"url,omitempty"`
+	TTLMinutes  int    `json:"ttl_minutes,omitempty"` // Voice call button lifetime
+	Payload     string `json:"payload,omitempty"`     // Voice call payload returned with the call
+
+	// Flow
+	FlowMessageVersion string             `json:"flow_message_version,omitempty"` // "3"
+	FlowToken          string             `json:"flow_token,omitempty"`
+	FlowID             string             `json:"flow_id,omitempty"`
+	FlowName           string             `json:"flow_name,omitempty"`
+	FlowCTA            string             `json:"flow_cta,omitempty"`
+	FlowAction         FlowAction         `json:"flow_action,omitempty"`
+	FlowActionPayload  *FlowActionPayload `json:"flow_action_payload,omitempty"`
+	Mode               FlowMode           `json:"mode,omitempty"`
+
+	// Catalog
+	ThumbnailProductRetailerID string `json:"thumbnail_product_retailer_id,omitempty"`
+
+	// Order details and order status
+	ReferenceID          string        `json:"reference_id,omitempty"`
+	OrderType            string        `json:"type,omitempty"`         // digital-goods, physical-goods
+	PaymentType          string        `json:"payment_type,omitempty"` // e.g., "upi"
+	PaymentConfiguration string        `json:"payment_configuration,omitempty"`
+	Currency             string        `json:"currency,omitempty"`
+	TotalAmount          *Amount       `json:"total_amount,omitempty"`
+	Order                *OrderDetails `json:"order,omitempty"`
+
+	// Address
+	Country          string            `json:"country,omitempty"` // IN, SG
+	Values           *AddressValues    `json:"values,omitempty"`  // Prefilled values
+	SavedAddresses   []SavedAddress    `json:"saved_addresses,omitempty"`
+	ValidationErrors map[string]string `json:"validation_errors,omitempty"` // Field name to error message
+}
+
+// ===============================
+// Addresses
+// ===============================
+
+// Countries supported by address messages
+const (
+	AddressCountryIndia     = "IN"
+	AddressCountrySingapore = "SG"
+)
+
+// AddressValues contains the fields of an address message. Which fields are
+// shown depends on the country.
+type AddressValues struct {
+	Name         string `json:"name,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+	InPinCode    string `json:"in_pin_code,omitempty"`  // India
+	SgPostCode   string `json:"sg_post_code,omitempty"` // Singapore
+	HouseNumber  string `json:"house_number,omitempty"`
+	FloorNumber  string `json:"floor_number,omitempty"`
+	TowerNumber  string `json:"tower_number,omitempty"`
+	BuildingName string `json:"building_name,omitempty"`
+	Address      string `json:"address,omitempty"`
+	LandmarkArea string `json:"landmark_area,omitempty"`
+	UnitNumber   string `json:"unit_number,omitempty"`
+	City         string `json:"city,omitempty"`
+	State        string `json:"state,omitempty"`
+}
+
+// SavedAddress is an address the user can pick instead of entering one.
+type SavedAddress struct {
+	ID    string        `json:"id"`
+	Value AddressValues `json:"value"`
+}
+
+// AddressSubmission is the response_json of an address message reply.
+type AddressSubmission struct {
+	SavedAddressID string        `json:"saved_address_id,omitempty"` // Set when a saved address was picked
+	Values         AddressValues `json:"values"`
+}
+
+// ===============================
+// Orders
+// ===============================
+
+// OrderStatus represents the status of an order.
+type OrderStatus string
+
+const (
+	OrderStatusPending          OrderStatus = "pending"
+	OrderStatusProcessing       OrderStatus = "processing"
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusCompleted        OrderStatus = "completed"
+	OrderStatusCanceled         OrderStatus = "canceled"
+)
+
+// Order types
+const (
+	OrderTypeDigitalGoods  = "digital-goods"
+	OrderTypePhysicalGoods = "physical-goods"
+)
+
+// Amount is a monetary amount expressed as Value / Offset, e.g. 12.50 is
+// {Value: 1250, Offset: 100}.
+type Amount struct {
+	Value  int64 `json:"value"`
+	Offset int64 `json:"offset"`
+}
+
+// OrderCharge is an amount with a description, used for tax, shipping and discount.
+type OrderCharge struct {
+	Value               int64  `json:"value"`
+	Offset              int64  `json:"offset"`
+	Description         string `json:"description,omitempty"`
+	DiscountProgramName string `json:"discount_program_name,omitempty"` // Discount only
+}
+
+// OrderDetails is the order of an order_details or order_status message.
+type OrderDetails struct {
+	Status      OrderStatus      `json:"status"`
+	Description string           `json:"description,omitempty"` // order_status only
+	CatalogID   string           `json:"catalog_id,omitempty"`
+	Expiration  *OrderExpiration `json:"expiration,omitempty"`
+	Items       []OrderItem      `json:"items,omitempty"`
+	Subtotal    *Amount          `json:"subtotal,omitempty"`
+	Tax         *OrderCharge     `json:"tax,omitempty"`
+	Shipping    *OrderCharge     `json:"shipping,omitempty"`
+	Discount    *OrderCharge     `json:"discount,omitempty"`
+}
+
+// OrderExpiration sets when a payment request expires.
+type OrderExpiration struct {
+	Timestamp   string `json:"timestamp"` // Unix time in seconds
+	Description string `json:"description"`
+}
+
+// OrderItem is an item of an order_details message.
+type OrderItem struct {
+	RetailerID      string           `json:"retailer_id"`
+	Name            string           `json:"name"`
+	Amount          Amount           `json:"amount"`
+	Quantity        int              `json:"quantity"`
+	SaleAmount      *Amount          `json:"sale_amount,omitempty"`
+	CountryOfOrigin string           `json:"country_of_origin,omitempty"`
+	ImporterName    string           `json:"importer_name,omitempty"`
+	ImporterAddress *ImporterAddress `json:"importer_address,omitempty"`
+}
+
+// ImporterAddress is the address of the importer of an order item.
+type ImporterAddress struct {
+	AddressLine1 string `json:"address_line1"`
+	AddressLine2 string `json:"address_line2,omitempty"`
+	City         string `json:"city"`
+	ZoneCode     string `json:"zone_code"`
+	PostalCode   string `json:"postal_code"`
+	CountryCode  string `json:"country_code"`
+}
+
+// CTAParameters represents CTA URL parameters.
+type CTAParameters = ActionParameters
+
+// ===============================
+// Flows
+// ===============================
+
+// FlowMessageVersion is the flow message version sent with flow messages.
+const FlowMessageVersion = "3"
+
+// FlowAction is the action performed when the flow CTA is tapped.
+type FlowAction string
+
+const (
+	FlowActionNavigate     FlowAction = "navigate"
+	FlowActionDataExchange FlowAction = "data_exchange"
+)
+
+// FlowMode selects which version of the flow is sent.
+type FlowMode string
+
+const (
+	FlowModeDraft     FlowMode = "draft"
+	FlowModePublished FlowMode = "published"
+)
+
+// FlowActionPayload contains the first screen and its initial data.
+// It is only used with FlowActionNavigate.
+type FlowActionPayload struct {
+	Screen string                 `json:"screen"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+}
+
+// ===============================
+// Template Messages
+// ===============================
+
+// TemplateContent represents template message content.
+type TemplateContent struct {
+	Name       string              `json:"name"`
+	Language   TemplateLanguage    `json:"language"`
+	Components []TemplateComponent `json:"components,omitempty"`
+}
+
+// TemplateLanguage represents template language settings.
+type TemplateLanguage struct {
+	Code string `json:"code"` // e.g., "en_US", "es", "pt_BR"
+}
+
+// TemplateComponentType represents the type of template component.
+type TemplateComponentType string
+
+const (
+	TemplateComponentHeader           TemplateComponentType = "header"
+	TemplateComponentBody             TemplateComponentType = "body"
+	TemplateComponentButton           TemplateComponentType = "button"
+	TemplateComponentCarousel         TemplateComponentType = "carousel"
+	TemplateComponentLimitedTimeOffer TemplateComponentType = "limited_time_offer"
+)
+
+// Template button sub types used when sending
+const (
+	TemplateButtonSubTypeQuickReply = "quick_reply"
+	TemplateButtonSubTypeURL        = "url" // Also used for OTP buttons
+	TemplateButtonSubTypeCopyCode   = "copy_code"
+	TemplateButtonSubTypeFlow       = "flow"
+)
+
+// TemplateComponent represents a component in a template.
+type TemplateComponent struct {
+	Type       TemplateComponentType `json:"type"`
+	SubType    string                `json:"sub_type,omitempty"` // For buttons: quick_reply, url, copy_code
+	Index      string                `json:"index,omitempty"`    // For buttons
+	Parameters []TemplateParameter   `json:"parameters,omitempty"`
+	Cards      []TemplateCard        `json:"cards,omitempty"` // For carousel
+}
+
+// TemplateCard represents a card of a carousel template message.
+type TemplateCard struct {
+	CardIndex  int                 `json:"card_index"`
+	Components []TemplateComponent `json:"components"`
+}
+
+// TemplateParameterType represents the type of template parameter.
+type TemplateParameterType string
+
+const (
+	TemplateParamText             TemplateParameterType = "text"
+	TemplateParamCurrency         TemplateParameterType = "currency"
+	TemplateParamDateTime         TemplateParameterType = "date_time"
+	TemplateParamImage            TemplateParameterType = "image"
+	TemplateParamDocument         TemplateParameterType = "document"
+	TemplateParamVideo            TemplateParameterType = "video"
+	TemplateParamPayload          TemplateParameterType = "payload"
+	TemplateParamLocation         TemplateParameterType = "location"
+	TemplateParamCouponCode       TemplateParameterType = "coupon_code"
+	TemplateParamLimitedTimeOffer TemplateParameterType = "limited_time_offer"
+	TemplateParamAction           TemplateParameterType = "action"
+)
+
+// TemplateParameter represents a parameter in a template component.
+type TemplateParameter struct {
+	Type             TemplateParameterType  `json:"type"`
+	Text             string                 `json:"text,omitempty"`
+	Currency         *CurrencyParam         `json:"currency,omitempty"`
+	DateTime         *DateTimeParam         `json:"date_time,omitempty"`
+	Image            *MediaContent          `json:"image,omitempty"`
+	Document         *DocumentContent       `json:"document,omitempty"`
+	Video            *MediaContent          `json:"video,omitempty"`
+	Payload          string                 `json:"payload,omitempty"` // For quick reply buttons
+	Location         *LocationContent       `json:"location,omitempty"`
+	CouponCode       string                 `json:"coupon_code,omitempty"` // For copy code buttons
+	LimitedTimeOffer *LimitedTimeOfferParam `json:"limited_time_offer,omitempty"`
+	Action           *TemplateActionParam   `json:"action,omitempty"` // For flow buttons
+
+	// ParameterName is set for templates using named parameters ({{first_name}})
+	ParameterName string `json:"parameter_name,omitempty"`
+}
+
+// CurrencyParam represents a currency parameter.
+type CurrencyParam struct {
+	FallbackValue string `json:"fallback_value"`
+	Code          string `json:"code"`        // ISO 4217 code
+	Amount1000    int64  `json:"amount_1000"` // Amount in thousandths
+}
+
+// LimitedTimeOfferParam sets the expiration of a limited-time offer.
+type LimitedTimeOfferParam struct {
+	ExpirationTimeMs int64 `json:"expiration_time_ms"` // Unix time in milliseconds
+}
+
+// TemplateActionParam represents the action of a flow button.
+type TemplateActionParam struct {
+	FlowToken      string                 `json:"flow_token,omitempty"`
+	FlowActionData map[string]interface{} `json:"flow_action_data,omitempty"`
+}
+
+// DateTimeParam represents a date/time parameter.
+type DateTimeParam struct {
+	FallbackValue string `json:"fallback_value"`
+}
+
+// ===============================
+// Business Profile
+// ===============================
+
+// BusinessProfile represents the WhatsApp Business Profile.
+type BusinessProfile struct {
+	MessagingProduct  string   `json:"messaging_product,omitempty"`
+	About             string   `json:"about,omitempty"`
+	Address           string   `json:"address,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	ProfilePictureURL string   `json:"profile_picture_url,omitempty"`
+	Websites          []string `json:"websites,omitempty"`
+	Vertical          string   `json:"vertical,omitempty"`
+}
+
+// BusinessProfileResponse is the response for business profile requests.
+type BusinessProfileResponse struct {
+	Data []BusinessProfile `json:"data"`
+}
+
+// ===============================
+// Phone Numbers
+// ===============================
+
+// PhoneNumber represents a WhatsApp phone number.
+type PhoneNumber struct {
+	ID                     string `json:"id"`
+	DisplayPhoneNumber     string `json:"display_phone_number"`
+	VerifiedName           string `json:"verified_name"`
+	QualityRating          string `json:"quality_rating"`
+	CodeVerificationStatus string `json:"code_verification_status,omitempty"`
+}
+
+// PhoneNumbersResponse is the response for phone numbers requests.
+type PhoneNumbersResponse struct {
+	Data   []PhoneNumber `json:"data"`
+	Paging *Paging       `json:"paging,omitempty"`
+}
+
+// Paging contains pagination information.
+type Paging struct {
+	Cursors Cursors `json:"cursors"`
+	Next    string  `json:"next,omitempty"`
+}
+
+// Cursors contains cursor information for pagination.
+type Cursors struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ===============================
+// Message Templates
+// ===============================
+
+// Template statuses
+const (
+	TemplateStatusApproved = "APPROVED"
+	TemplateStatusPending  = "PENDING"
+	TemplateStatusRejected = "REJECTED"
+	TemplateStatusPaused   = "PAUSED"
+	TemplateStatusDisabled = "DISABLED"
+)
+
+// Template categories
+const (
+	TemplateCategoryMarketing      = "MARKETING"
+	TemplateCategoryUtility        = "UTILITY"
+	TemplateCategoryAuthentication = "AUTHENTICATION"
+)
+
+// Template represents a message template.
+type Template struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Status          string                 `json:"status"`
+	Category        string                 `json:"category"`
+	Language        string                 `json:"language"`
+	Components      []TemplateComponentDef `json:"components,omitempty"`
+	QualityScore    *TemplateQualityScore  `json:"quality_score,omitempty"`
+	ParameterFormat string                 `json:"parameter_format,omitempty"` // POSITIONAL (default) or NAMED
+}
+
+// Template parameter formats
+const (
+	TemplateParameterFormatPositional = "POSITIONAL"
+	TemplateParameterFormatNamed      = "NAMED"
+)
+
+// TemplateQualityScore represents the quality rating of a template.
+type TemplateQualityScore struct {
+	Score string `json:"score"` // GREEN, YELLOW, RED, UNKNOWN
+	Date  int64  `json:"date,omitempty"`
+}
+
+// Template definition component types
+const (
+	TemplateDefHeader           = "HEADER"
+	TemplateDefBody             = "BODY"
+	TemplateDefFooter           = "FOOTER"
+	TemplateDefButtons          = "BUTTONS"
+	TemplateDefCarousel         = "CAROUSEL"
+	TemplateDefLimitedTimeOffer = "LIMITED_TIME_OFFER"
+)
+
+// Template header formats
+const (
+	TemplateFormatText     = "TEXT"
+	TemplateFormatImage    = "IMAGE"
+	TemplateFormatVideo    = "VIDEO"
+	TemplateFormatDocument = "DOCUMENT"
+	TemplateFormatLocation = "LOCATION"
+)
+
+// Template button types
+const (
+	TemplateButtonQuickReply  = "QUICK_REPLY"
+	TemplateButtonURL         = "URL"
+	TemplateButtonPhoneNumber = "PHONE_NUMBER"
+	TemplateButtonOTP         = "OTP"
+	TemplateButtonCopyCode    = "COPY_CODE"
+	TemplateButtonFlow        = "FLOW"
+)
+
+// TemplateComponentDef represents a template component definition.
+type TemplateComponentDef struct {
+	Type    string              `json:"type"`
+	Format  string              `json:"format,omitempty"`
+	Text    string              `json:"text,omitempty"`
+	Buttons []TemplateButtonDef `json:"buttons,omitempty"`
+	Example *TemplateExample    `json:"example,omitempty"`
+
+	// Authentication templates only
+	AddSecurityRecommendation bool `json:"add_security_recommendation,omitempty"` // BODY
+	CodeExpirationMinutes     int  `json:"code_expiration_minutes,omitempty"`     // FOOTER, 1-90
+
+	Cards            []TemplateCardDef    `json:"cards,omitempty"`              // CAROUSEL
+	LimitedTimeOffer *LimitedTimeOfferDef `json:"limited_time_offer,omitempty"` // LIMITED_TIME_OFFER
+}
+
+// TemplateCardDef represents a card of a carousel template definition.
+// Each card has an IMAGE or VIDEO header, a body and buttons.
+type TemplateCardDef struct {
+	Components []TemplateComponentDef `json:"components"`
+}
+
+// LimitedTimeOfferDef represents the offer of a limited-time-offer template.
+type LimitedTimeOfferDef struct {
+	Text          string `json:"text"`
+	HasExpiration bool   `json:"has_expiration,omitempty"`
+}
+
+// OTP button types for authentication templates
+const (
+	OTPTypeCopyCode = "COPY_CODE"
+	OTPTypeOneTap   = "ONE_TAP"
+	OTPTypeZeroTap  = "ZERO_TAP"
+)
+
+// TemplateButtonDef represents a button in a template definition.
+type TemplateButtonDef struct {
+	Type        string   `json:"type"`
+	Text        string   `json:"text,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	PhoneNumber string   `json:"phone_number,omitempty"`
+	Example     []string `json:"example,omitempty"` // Sample URL for URL buttons, or the coupon code for COPY_CODE
+
+	// OTP buttons only
+	OTPType              string            `json:"otp_type,omitempty"`      // COPY_CODE, ONE_TAP, ZERO_TAP
+	AutofillText         string            `json:"autofill_text,omitempty"` // One-tap button text
+	PackageName          string            `json:"package_name,omitempty"`
+	SignatureHash        string            `json:"signature_hash,omitempty"`
+	SupportedApps        []OTPSupportedApp `json:"supported_apps,omitempty"`
+	ZeroTapTermsAccepted bool              `json:"zero_tap_terms_accepted,omitempty"`
+
+	// FLOW buttons only; set one of FlowID, FlowName or FlowJSON
+	FlowID         string `json:"flow_id,omitempty"`
+	FlowName       string `json:"flow_name,omitempty"`
+	FlowJSON       string `json:"flow_json,omitempty"`
+	FlowAction     string `json:"flow_action,omitempty"`     // navigate, data_exchange
+	NavigateScreen string `json:"navigate_screen,omitempty"` // First screen for navigate
+}
+
+// MarshalJSON encodes the example of a COPY_CODE button as a string, as the
+// API expects, and URL button examples as an array.
+func (b TemplateButtonDef) MarshalJSON() ([]byte, error) {
+	type buttonDef TemplateButtonDef
+	if !strings.EqualFold(b.Type, TemplateButtonCopyCode) || len(b.Example) == 0 {
+		return json.Marshal(buttonDef(b))
+	}
+	return json.Marshal(struct {
+		buttonDef
+		Example string `json:"example"`
+	}{buttonDef(b), b.Example[0]})
+}
+
+// UnmarshalJSON accepts the example as either a string or an array.
+func (b *TemplateButtonDef) UnmarshalJSON(data []byte) error {
+	type buttonDef TemplateButtonDef
+	var raw struct {
+		buttonDef
+		Example json.RawMessage `json:"example,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*b = TemplateButtonDef(raw.buttonDef)
+	b.Example = nil
+
+	if len(raw.Example) == 0 {
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(raw.Example, &single); err == nil {
+		b.Example = []string{single}
+		return nil
+	}
+	return json.Unmarshal(raw.Example, &b.Example)
+}
+
+// OTPSupportedApp identifies an Android app that can receive one-tap or zero-tap codes.
+type OTPSupportedApp struct {
+	PackageName   string `json:"package_name"`
+	SignatureHash string `json:"signature_hash"`
+}
+
+// TemplateExample represents example values for a template.
+type TemplateExample struct {
+	HeaderText   []string   `json:"header_text,omitempty"`
+	BodyText     [][]string `json:"body_text,omitempty"`
+	HeaderHandle []string   `json:"header_handle,omitempty"`
+
+	// Examples for templates using named parameters
+	HeaderTextNamedParams []NamedParamExample `json:"header_text_named_params,omitempty"`
+	BodyTextNamedParams   []NamedParamExample `json:"body_text_named_params,omitempty"`
+}
+
+// NamedParamExample is an example value for a named template parameter.
+type NamedParamExample struct {
+	ParamName string `json:"param_name"`
+	Example   string `json:"example"`
+}
+
+// TemplatesResponse is the response for templates requests.
+type TemplatesResponse struct {
+	Data   []Template `json:"data"`
+	Paging *Paging    `json:"paging,omitempty"`
+}
+
+// ===============================
+// Flow Management
+// ===============================
+
+// FlowStatus represents the lifecycle status of a flow.
+type FlowStatus string
+
+const (
+	FlowStatusDraft      FlowStatus = "DRAFT"
+	FlowStatusPublished  FlowStatus = "PUBLISHED"
+	FlowStatusDeprecated FlowStatus = "DEPRECATED"
+	FlowStatusBlocked    FlowStatus = "BLOCKED"
+	FlowStatusThrottled  FlowStatus = "THROTTLED"
+)
+
+// Flow categories
+const (
+	FlowCategorySignUp             = "SIGN_UP"
+	FlowCategorySignIn             = "SIGN_IN"
+	FlowCategoryAppointmentBooking = "APPOINTMENT_BOOKING"
+	FlowCategoryLeadGeneration     = "LEAD_GENERATION"
+	FlowCategoryContactUs          = "CONTACT_US"
+	FlowCategoryCustomerSupport    = "CUSTOMER_SUPPORT"
+	FlowCategorySurvey             = "SURVEY"
+	FlowCategoryOther              = "OTHER"
+)
+
+// Flow represents a flow on the WhatsApp Business Account.
+type Flow struct {
+	ID               string               `json:"id"`
+	Name             string               `json:"name"`
+	Status           FlowStatus           `json:"status"`
+	Categories       []string             `json:"categories,omitempty"`
+	ValidationErrors FlowValidationErrors `json:"validation_errors,omitempty"`
+	JSONVersion      string               `json:"json_version,omitempty"`
+	DataAPIVersion   string               `json:"data_api_version,omitempty"`
+	EndpointURI      string               `json:"endpoint_uri,omitempty"`
+	Preview          *FlowPreview         `json:"preview,omitempty"`
+}
+
+// FlowPreview contains a web preview link of a flow.
+type FlowPreview struct {
+	PreviewURL string `json:"preview_url"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+// FlowValidationError describes a problem found in a Flow JSON.
+type FlowValidationError struct {
+	Code        string             `json:"error"` // e.g., INVALID_PROPERTY_VALUE
+	ErrorType   string             `json:"error_type"`
+	Message     string             `json:"message"`
+	LineStart   int                `json:"line_start,omitempty"`
+	LineEnd     int                `json:"line_end,omitempty"`
+	ColumnStart int                `json:"column_start,omitempty"`
+	ColumnEnd   int                `json:"column_end,omitempty"`
+	Pointers    []FlowErrorPointer `json:"pointers,omitempty"`
+}
+
+// FlowErrorPointer locates a validation error in the Flow JSON.
+type FlowErrorPointer struct {
+	LineStart   int    `json:"line_start"`
+	LineEnd     int    `json:"line_end"`
+	ColumnStart int    `json:"column_start"`
+	ColumnEnd   int    `json:"column_end"`
+	Path        string `json:"path"`
+}
+
+// FlowValidationErrors is the list of validation errors of a Flow JSON.
+// A flow with validation errors can be saved but not published.
+type FlowValidationErrors []FlowValidationError
+
+// Error implements the error interface.
+func (e FlowValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = fmt.Sprintf("%s (line %d, column %d): %s", v.Code, v.LineStart, v.ColumnStart, v.Message)
+	}
+	return "flow JSON is invalid: " + strings.Join(msgs, "; ")
+}
+
+// FlowCreateResponse is the response of creating a flow.
+type FlowCreateResponse struct {
+	ID               string               `json:"id"`
+	Success          bool                 `json:"success,omitempty"`
+	ValidationErrors FlowValidationErrors `json:"validation_errors,omitempty"`
+}
+
+// FlowAsset represents an asset of a flow, such as its Flow JSON.
+type FlowAsset struct {
+	Name        string `json:"name"`
+	AssetType   string `json:"asset_type"` // FLOW_JSON
+	DownloadURL string `json:"download_url"`
+}
+
+// ===============================
+// Resumable Uploads
+// ===============================
+
+// UploadSession represents a resumable upload session.
+type UploadSession struct {
+	ID         string `json:"id"`                    // "upload:<SESSION_ID>"
+	FileOffset int64  `json:"file_offset,omitempty"` // Bytes already received by the server
+	Handle     string `json:"h,omitempty"`           // Set once the whole file has been received
+}
+
+// UploadHandleResponse is the response once an upload session is complete.
+type UploadHandleResponse struct {
+	Handle string `json:"h"`
+}
+
+// ===============================
+// Webhook Events
+// ===============================
+
+// WebhookPayload is the payload received from webhook callbacks.
+type WebhookPayload struct {
+	Object string         `json:"object"`
+	Entry  []WebhookEntry `json:"entry"`
+}
+
+// WebhookEntry represents an entry in the webhook payload.
+type WebhookEntry struct {
+	ID      string          `json:"id"`
+	Changes []WebhookChange `json:"changes"`
+}
+
+// WebhookChange represents a change in the webhook entry.
+type WebhookChange struct {
+	Value WebhookValue `json:"value"`
+	Field string       `json:"field"`
+}
+
+// WebhookValue contains the actual webhook data.
+type WebhookValue struct {
+	MessagingProduct string                `json:"messaging_product"`
+	Metadata         WebhookMetadata       `json:"metadata"`
+	Contacts         []WebhookContact      `json:"contacts,omitempty"`
+	Messages         []IncomingMessage     `json:"messages,omitempty"`
+	Statuses         []MessageStatusUpdate `json:"statuses,omitempty"`
+	Errors           []WebhookError        `json:"errors,omitempty"`
+}
+
+// WebhookMetadata contains metadata about the webhook.
+type WebhookMetadata struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	PhoneNumberID      string `json:"phone_number_id"`
+}
+
+// WebhookContact represents a contact in the webhook.
+type WebhookContact struct {
+	WaID    string         `json:"wa_id"`
+	Profile ContactProfile `json:"profile"`
+}
+
+// ContactProfile represents the profile of a contact.
+type ContactProfile struct {
+	Name string `json:"name"`
+}
+
+// IncomingMessage represents an incoming message.
+type IncomingMessage struct {
+	ID        string          `json:"id"`
+	From      string          `json:"from"`
+	Timestamp string          `json:"timestamp"`
+	Type      MessageType     `json:"type"`
+	Context   *MessageContext `json:"context,omitempty"`
+	Errors    []WebhookError  `json:"errors,omitempty"`
+
+	// Message content based on type
+	Text        *IncomingText        `json:"text,omitempty"`
+	Image       *IncomingMedia       `json:"image,omitempty"`
+	Audio       *IncomingMedia       `json:"audio,omitempty"`
+	Video       *IncomingMedia       `json:"video,omitempty"`
+	Document    *IncomingDocument    `json:"document,omitempty"`
+	Sticker     *IncomingMedia       `json:"sticker,omitempty"`
+	Location    *LocationContent     `json:"location,omitempty"`
+	Contacts    []ContactContent     `json:"contacts,omitempty"`
+	Interactive *IncomingInteractive `json:"interactive,omitempty"`
+	Button      *IncomingButton      `json:"button,omitempty"`
+	Reaction    *IncomingReaction    `json:"reaction,omitempty"`
+	Order       *IncomingOrder       `json:"order,omitempty"`
+	Referral    *Referral            `json:"referral,omitempty"`
+	System      *SystemMessage       `json:"system,omitempty"`
+}
+
+// MessageContext represents the context of a replied message.
+type MessageContext struct {
+	From      string `json:"from"`
+	ID        string `json:"id"`
+	Forwarded bool   `json:"forwarded,omitempty"`
+}
+
+// IncomingText represents incoming text content.
+type IncomingText struct {
+	Body string `json:"body"`
+}
+
+// IncomingMedia represents incoming media content.
+type IncomingMedia struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mime_type"`
+	SHA256   string `json:"sha256"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+// IncomingDocument represents an incoming document.
+type IncomingDocument struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mime_type"`
+	SHA256   string `json:"sha256"`
+	Filename string `json:"filename,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+// IncomingInteractive represents an interactive response.
+type IncomingInteractive struct {
+	Type        string                `json:"type"` // button_reply, list_reply, nfm_reply
+	ButtonReply *InteractiveReply     `json:"button_reply,omitempty"`
+	ListReply   *InteractiveListReply `json:"list_reply,omitempty"`
+	NfmReply    *NfmReply             `json:"nfm_reply,omitempty"`
+}
+
+// NfmReply represents the reply sent when a user completes a flow or
+// submits an address message.
+type NfmReply struct {
+	Name         string `json:"name"` // "flow", "address_message"
+	Body         string `json:"body"`
+	ResponseJSON string `json:"response_json"`
+}
+
+// InteractiveListReply represents a list reply.
+type InteractiveListReply struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// IncomingButton represents a button response.
+type IncomingButton struct {
+	Text    string `json:"text"`
+	Payload string `json:"payload"`
+}
+
+// IncomingOrder represents an order placed from a cart.
+type IncomingOrder struct {
+	CatalogID    string             `json:"catalog_id"`
+	Text         string             `json:"text,omitempty"`
+	ProductItems []OrderProductItem `json:"product_items"`
+}
+
+// OrderProductItem is a product of an incoming order. Quantity and price may
+// arrive as JSON numbers or strings.
+type OrderProductItem struct {
+	ProductRetailerID string      `json:"product_retailer_id"`
+	Quantity          json.Number `json:"quantity"`
+	ItemPrice         json.Number `json:"item_price"`
+	Currency          string      `json:"currency"`
+}
+
+// Total returns the quantity multiplied by the item price as an exact
+// amount with at least two decimal places, e.g. 3 × 12.5 is
+// {Value: 3750, Offset: 100}. It fails if the quantity is not a whole number
+// or the price is not a decimal number.
+func (i *OrderProductItem) Total() (Amount, error) {
+	quantity, err := strconv.ParseInt(string(i.Quantity), 10, 64)
+	if err != nil || quantity < 0 {
+		return Amount{}, fmt.Errorf("invalid quantity %q for product %s", i.Quantity, i.ProductRetailerID)
+	}
+	price, err := parseDecimalAmount(string(i.ItemPrice))
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid item price %q for product %s", i.ItemPrice, i.ProductRetailerID)
+	}
+
+	value, ok := mulInt64(price.Value, quantity)
+	if !ok {
+		return Amount{}, fmt.Errorf("total of product %s is out of range", i.ProductRetailerID)
+	}
+	return Amount{Value: value, Offset: price.Offset}, nil
+}
+
+// Total returns the exact sum of all item totals, using the largest offset
+// of the items. It fails if an item is invalid, the items use different
+// currencies or the total does not fit in an Amount at that offset.
+func (o *IncomingOrder) Total() (Amount, error) {
+	total := Amount{Offset: 100}
+	for idx := range o.ProductItems {
+		item := &o.ProductItems[idx]
+		if item.Currency != o.ProductItems[0].Currency {
+			return Amount{}, fmt.Errorf("order mixes currencies %s and %s", o.ProductItems[0].Currency, item.Currency)
+		}
+
+		amount, err := item.Total()
+		if err != nil {
+			return Amount{}, err
+		}
+
+		// Bring both amounts to the larger offset; offsets are powers of ten
+		var ok bool
+		if total, ok = total.rescale(amount.Offset); !ok {
+			return Amount{}, fmt.Errorf("order total is out of range at offset %d", amount.Offset)
+		}
+		if amount, ok = amount.rescale(total.Offset); !ok {
+			return Amount{}, fmt.Errorf("total of product %s is out of range at offset %d", item.ProductRetailerID, total.Offset)
+		}
+		if total.Value, ok = addInt64(total.Value, amount.Value); !ok {
+			return Amount{}, fmt.Errorf("order total is out of range")
+		}
+	}
+	return total, nil
+}
+
+// rescale returns a expressed with the given offset if that is larger than
+// its own, e.g. {1250, 100} at offset 1000 is {12500, 1000}. Offsets are
+// powers of ten. It returns false if the value overflows.
+func (a Amount) rescale(offset int64) (Amount, bool) {
+	for a.Offset < offset {
+		value, ok := mulInt64(a.Value, 10)
+		if !ok {
+			return Amount{}, false
+		}
+		a.Value = value
+		a.Offset *= 10
+	}
+	return a, true
+}
+
+// addInt64 returns a + b and false if the sum overflows.
+func addInt64(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// subInt64 returns a - b and false if the difference overflows.
+func subInt64(a, b int64) (int64, bool) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// mulInt64 returns a * b and false if the product overflows.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// parseDecimalAmount parses a decimal number such as "12.5" into an exact
+// amount with at least two decimal places, e.g. {Value: 1250, Offset: 100}.
+func parseDecimalAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) < 2 {
+		frac += strings.Repeat("0", 2-len(frac))
+	}
+	if whole == "" || whole == "-" || strings.ContainsAny(frac, "+-") || len(frac) > 18 {
+		return Amount{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	value, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	offset := int64(1)
+	for range frac {
+		offset *= 10
+	}
+	return Amount{Value: value, Offset: offset}, nil
+}
+
+// IncomingReaction represents an incoming reaction.
+type IncomingReaction struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"`
+}
+
+// Referral represents click-to-WhatsApp referral data.
+type Referral struct {
+	SourceURL    string `json:"source_url"`
+	SourceType   string `json:"source_type"`
+	SourceID     string `json:"source_id"`
+	Headline     string `json:"headline,omitempty"`
+	Body         string `json:"body,omitempty"`
+	MediaType    string `json:"media_type,omitempty"`
+	ImageURL     string `json:"image_url,omitempty"`
+	VideoURL     string `json:"video_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// SystemMessage represents a system-generated message.
+type SystemMessage struct {
+	Type     string `json:"type"` // customer_changed_number, customer_identity_changed
+	Body     string `json:"body"`
+	NewWaID  string `json:"new_wa_id,omitempty"`
+	Identity string `json:"identity,omitempty"`
+}
+
+// System message types
+const (
+	SystemCustomerChangedNumber   = "customer_changed_number"
+	SystemCustomerIdentityChanged = "customer_identity_changed"
+)
+
+// MessageStatusUpdate represents a message status update.
+type MessageStatusUpdate struct {
+	ID           string         `json:"id"`
+	Status       MessageStatus  `json:"status"`
+	Timestamp    string         `json:"timestamp"`
+	RecipientID  string         `json:"recipient_id"`
+	Conversation *Conversation  `json:"conversation,omitempty"`
+	Pricing      *Pricing       `json:"pricing,omitempty"`
+	Errors       []WebhookError `json:"errors,omitempty"`
+}
+
+// Conversation contains conversation information.
+type Conversation struct {
+	ID                  string             `json:"id"`
+	Origin              ConversationOrigin `json:"origin"`
+	ExpirationTimestamp string             `json:"expiration_timestamp,omitempty"`
+}
+
+// ConversationOrigin represents the origin of a conversation.
+type ConversationOrigin struct {
+	Type string `json:"type"` // user_initiated, business_initiated, referral_conversion
+}
+
+// Pricing contains pricing information for a message.
+type Pricing struct {
+	PricingModel string `json:"pricing_model"`
+	Billable     bool   `json:"billable"`
+	Category     string `json:"category"`
+}
+
+// WebhookError represents an error in a webhook.
+type WebhookError struct {
+	Code    int    `json:"code"`
+	Title   string `json:"title"`
+	Message string `json:"message,omitempty"`
+	Details string `json:"error_data,omitempty"`
+}
+
+// ===============================
+// Utility Types
+// ===============================
+
+// Timestamp is a custom time type for JSON parsing.
+type Timestamp time.Time
+
+// UnmarshalJSON parses a Unix timestamp.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	var ts int64
+	if err := json.Unmarshal(b, &ts); err != nil {
+		// Try as string
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		ts, _ = strconv.ParseInt(s, 10, 64)
+	}
+	*t = Timestamp(time.Unix(ts, 0))
+	return nil
+}
+
+// Time returns the time.Time value.
+func (t Timestamp) Time() time.Time {
+	return time.Time(t)
+}
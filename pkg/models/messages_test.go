@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestOrderProductItemTotal(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+		price    string
+		want     Amount
+		wantErr  bool
+	}{
+		{"whole price", "3", "12", Amount{Value: 3600, Offset: 100}, false},
+		{"one decimal", "3", "12.5", Amount{Value: 3750, Offset: 100}, false},
+		{"three decimals", "2", "0.125", Amount{Value: 250, Offset: 1000}, false},
+		{"zero quantity", "0", "9.99", Amount{Value: 0, Offset: 100}, false},
+		{"fractional quantity", "1.5", "10", Amount{}, true},
+		{"negative quantity", "-1", "10", Amount{}, true},
+		{"invalid price", "1", "1,50", Amount{}, true},
+		{"overflow", "10", strconv.FormatInt(math.MaxInt64/100, 10), Amount{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := OrderProductItem{ProductRetailerID: "sku", Quantity: json.Number(tt.quantity), ItemPrice: json.Number(tt.price)}
+			got, err := item.Total()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Total error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Total = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomingOrderTotal(t *testing.T) {
+	item := func(quantity, price, currency string) OrderProductItem {
+		return OrderProductItem{ProductRetailerID: "sku", Quantity: json.Number(quantity), ItemPrice: json.Number(price), Currency: currency}
+	}
+
+	tests := []struct {
+		name    string
+		items   []OrderProductItem
+		want    Amount
+		wantErr bool
+	}{
+		{"empty", nil, Amount{Value: 0, Offset: 100}, false},
+		{"same offset", []OrderProductItem{item("2", "1.50", "USD"), item("1", "0.25", "USD")}, Amount{Value: 325, Offset: 100}, false},
+		{"rescales total", []OrderProductItem{item("1", "1.50", "USD"), item("1", "0.125", "USD")}, Amount{Value: 1625, Offset: 1000}, false},
+		{"rescales item", []OrderProductItem{item("1", "0.125", "USD"), item("1", "1.50", "USD")}, Amount{Value: 1625, Offset: 1000}, false},
+		{"mixed currencies", []OrderProductItem{item("1", "1", "USD"), item("1", "1", "EUR")}, Amount{}, true},
+		{"rescale overflow", []OrderProductItem{item("1", "90000000000000000", "USD"), item("1", "0.001", "USD")}, Amount{}, true},
+		{"sum overflow", []OrderProductItem{item("1", "90000000000000000", "USD"), item("1", "90000000000000000", "USD")}, Amount{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := IncomingOrder{ProductItems: tt.items}
+			got, err := order.Total()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Total error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Total = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountRescale(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		offset int64
+		want   Amount
+		ok     bool
+	}{
+		{"larger offset", Amount{Value: 1250, Offset: 100}, 1000, Amount{Value: 12500, Offset: 1000}, true},
+		{"same offset", Amount{Value: 1250, Offset: 100}, 100, Amount{Value: 1250, Offset: 100}, true},
+		{"smaller offset", Amount{Value: 1250, Offset: 100}, 10, Amount{Value: 1250, Offset: 100}, true},
+		{"negative", Amount{Value: -5, Offset: 1}, 100, Amount{Value: -500, Offset: 100}, true},
+		{"overflow", Amount{Value: math.MaxInt64 / 5, Offset: 1}, 10, Amount{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.amount.rescale(tt.offset)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("rescale = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(a, b int64) (int64, bool)
+		a, b int64
+		want int64
+		ok   bool
+	}{
+		{"add", addInt64, 2, 3, 5, true},
+		{"add overflow", addInt64, math.MaxInt64, 1, 0, false},
+		{"add underflow", addInt64, math.MinInt64, -1, 0, false},
+		{"sub", subInt64, 2, 3, -1, true},
+		{"sub overflow", subInt64, math.MaxInt64, -1, 0, false},
+		{"sub underflow", subInt64, math.MinInt64, 1, 0, false},
+		{"mul", mulInt64, -4, 5, -20, true},
+		{"mul by zero", mulInt64, math.MaxInt64, 0, 0, true},
+		{"mul overflow", mulInt64, math.MaxInt64/2 + 1, 2, 0, false},
+		{"mul min by -1", mulInt64, math.MinInt64, -1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.fn(tt.a, tt.b)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("got %d, %v, want %d, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
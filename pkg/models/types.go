@@ -1,10 +1,6 @@
-package models
-
-import (
-	"encoding/json"
-	"strconv"
-	"time"
-)
-
-// Ensure Timestamp implements json.Unmarshaler
-var _ json.Unmarshaler = (*Timestamp)(nil)
+package models
+
+import "encoding/json"
+
+// Ensure Timestamp implements json.Unmarshaler
+var _ json.Unmarshaler = (*Timestamp)(nil)
@@ -0,0 +1,649 @@
+// Package models provides validation of outgoing messages against the
+// documented WhatsApp Cloud API limits.
+package models
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+)
+
+// Message limits, in characters
+const (
+	MaxTextBodyLength          = 4096
+	MaxCaptionLength           = 1024
+	MaxInteractiveHeaderLength = 60
+	MaxInteractiveBodyLength   = 1024
+	MaxInteractiveFooterLength = 60
+	MaxReplyButtons            = 3
+	MaxReplyButtonIDLength     = 256
+	MaxReplyButtonTitleLength  = 20
+	MaxListButtonLength        = 20
+	MaxListSections            = 10
+	MaxListRows                = 10
+	MaxListRowIDLength         = 200
+	MaxListRowTitleLength      = 24
+	MaxListRowDescLength       = 72
+	MaxSectionTitleLength      = 24
+	MaxCTADisplayTextLength    = 20
+	MaxFlowCTALength           = 20
+	MaxTemplateNameLength      = 512
+	MaxTemplateCarouselCards   = 10
+)
+
+// Validate checks the request against the documented API limits for its
+// message type and returns every violation as errors.ValidationErrors, or
+// nil if the request is valid.
+func (r *MessageRequest) Validate() error {
+	var errs errors.ValidationErrors
+
+	if r.To == "" {
+		errs.Add("to", "recipient phone number is required")
+	}
+	if r.Context != nil && r.Context.MessageID == "" {
+		errs.Add("context.message_id", "message ID is required when replying")
+	}
+
+	switch r.Type {
+	case MessageTypeText:
+		if r.Text == nil {
+			errs.Add("text", "text content is required")
+			break
+		}
+		checkRequired(&errs, "text.body", r.Text.Body, MaxTextBodyLength)
+
+	case MessageTypeImage, MessageTypeVideo:
+		media := r.Image
+		if r.Type == MessageTypeVideo {
+			media = r.Video
+		}
+		validateMedia(&errs, string(r.Type), media, true)
+
+	case MessageTypeAudio:
+		validateMedia(&errs, "audio", r.Audio, false)
+
+	case MessageTypeSticker:
+		validateMedia(&errs, "sticker", r.Sticker, false)
+
+	case MessageTypeDocument:
+		if r.Document == nil {
+			errs.Add("document", "document content is required")
+			break
+		}
+		validateSource(&errs, "document", r.Document.ID, r.Document.Link, r.Document.Path)
+		checkLength(&errs, "document.caption", r.Document.Caption, MaxCaptionLength)
+
+	case MessageTypeLocation:
+		if r.Location == nil {
+			errs.Add("location", "location content is required")
+			break
+		}
+		if r.Location.Latitude < -90 || r.Location.Latitude > 90 {
+			errs.Add("location.latitude", "latitude must be between -90 and 90")
+		}
+		if r.Location.Longitude < -180 || r.Location.Longitude > 180 {
+			errs.Add("location.longitude", "longitude must be between -180 and 180")
+		}
+
+	case MessageTypeContacts:
+		if len(r.Contacts) == 0 {
+			errs.Add("contacts", "at least one contact is required")
+		}
+		for i, contact := range r.Contacts {
+			if contact.Name.FormattedName == "" {
+				errs.Add(fmt.Sprintf("contacts[%d].name.formatted_name", i), "formatted name is required")
+			}
+		}
+
+	case MessageTypeReaction:
+		if r.Reaction == nil {
+			errs.Add("reaction", "reaction content is required")
+			break
+		}
+		if r.Reaction.MessageID == "" {
+			errs.Add("reaction.message_id", "message ID is required")
+		}
+
+	case MessageTypeTemplate:
+		if r.Template == nil {
+			errs.Add("template", "template content is required")
+			break
+		}
+		checkRequired(&errs, "template.name", r.Template.Name, MaxTemplateNameLength)
+		if r.Template.Language.Code == "" {
+			errs.Add("template.language.code", "language code is required")
+		}
+		validateTemplateComponents(&errs, "template.components", r.Template.Components)
+
+	case MessageTypeInteractive:
+		if r.Interactive == nil {
+			errs.Add("interactive", "interactive content is required")
+			break
+		}
+		r.Interactive.validate(&errs)
+
+	case "":
+		errs.Add("type", "message type is required")
+
+	default:
+		errs.Add("type", fmt.Sprintf("unsupported message type %q", r.Type))
+	}
+
+	return errs.ErrOrNil()
+}
+
+// validate checks an interactive message's header, body, footer and action.
+func (c *InteractiveContent) validate(errs *errors.ValidationErrors) {
+	if h := c.Header; h != nil {
+		switch h.Type {
+		case "text":
+			checkRequired(errs, "interactive.header.text", h.Text, MaxInteractiveHeaderLength)
+		case "image", "video", "document":
+			media := h.Image
+			if h.Type == "video" {
+				media = h.Video
+			} else if h.Type == "document" {
+				media = h.Document
+			}
+			field := "interactive.header." + h.Type
+			if media == nil {
+				errs.Add(field, h.Type+" header content is required")
+			} else {
+				validateSource(errs, field, media.ID, media.Link, media.Path)
+			}
+		default:
+			errs.Add("interactive.header.type", fmt.Sprintf("unsupported header type %q", h.Type))
+		}
+	}
+
+	// Single-product messages are the only ones where the body is optional
+	if c.Type == InteractiveTypeProduct {
+		checkLength(errs, "interactive.body.text", c.Body.Text, MaxInteractiveBodyLength)
+	} else {
+		checkRequired(errs, "interactive.body.text", c.Body.Text, MaxInteractiveBodyLength)
+	}
+	if c.Footer != nil {
+		checkLength(errs, "interactive.footer.text", c.Footer.Text, MaxInteractiveFooterLength)
+	}
+
+	action := &c.Action
+	params := action.Parameters
+	switch c.Type {
+	case InteractiveTypeButton:
+		validateReplyButtons(errs, action.Buttons)
+
+	case InteractiveTypeList:
+		checkRequired(errs, "interactive.action.button", action.Button, MaxListButtonLength)
+		validateListSections(errs, action.Sections)
+
+	case InteractiveTypeCTA:
+		if params == nil {
+			errs.Add("interactive.action.parameters", "CTA parameters are required")
+			break
+		}
+		checkRequired(errs, "interactive.action.parameters.display_text", params.DisplayText, MaxCTADisplayTextLength)
+		if params.URL == "" {
+			errs.Add("interactive.action.parameters.url", "URL is required")
+		}
+
+	case InteractiveTypeFlow:
+		if params == nil {
+			errs.Add("interactive.action.parameters", "flow parameters are required")
+			break
+		}
+		checkRequired(errs, "interactive.action.parameters.flow_cta", params.FlowCTA, MaxFlowCTALength)
+		if params.FlowID == "" && params.FlowName == "" {
+			errs.Add("interactive.action.parameters.flow_id", "either flow ID or flow name is required")
+		}
+
+	case InteractiveTypeProduct:
+		if action.CatalogID == "" {
+			errs.Add("interactive.action.catalog_id", "catalog ID is required")
+		}
+		if action.ProductRetailerID == "" {
+			errs.Add("interactive.action.product_retailer_id", "product retailer ID is required")
+		}
+
+	case InteractiveTypeProductList:
+		if c.Header == nil || c.Header.Type != "text" {
+			errs.Add("interactive.header", "product list messages require a text header")
+		}
+		if action.CatalogID == "" {
+			errs.Add("interactive.action.catalog_id", "catalog ID is required")
+		}
+		validateProductSections(errs, action.Sections)
+
+	case InteractiveTypeVoiceCall:
+		if params == nil {
+			errs.Add("interactive.action.parameters", "voice call parameters are required")
+			break
+		}
+		checkRequired(errs, "interactive.action.parameters.display_text", params.DisplayText, MaxCTADisplayTextLength)
+		if params.TTLMinutes < 0 {
+			errs.Add("interactive.action.parameters.ttl_minutes", "TTL must not be negative")
+		}
+
+	case InteractiveTypeCatalog:
+		if c.Header != nil {
+			errs.Add("interactive.header", "catalog messages do not support a header")
+		}
+
+	case InteractiveTypeOrderDetails:
+		validateOrderDetails(errs, params)
+
+	case InteractiveTypeOrderStatus:
+		validateOrderStatus(errs, params)
+
+	case InteractiveTypeLocationRequest:
+		if c.Header != nil || c.Footer != nil {
+			errs.Add("interactive", "location request messages only support a body")
+		}
+
+	case InteractiveTypeAddress:
+		validateAddress(errs, params)
+
+	case InteractiveTypeCallPermission:
+		if c.Header != nil && c.Header.Type != "text" {
+			errs.Add("interactive.header", "call permission requests only support text headers")
+		}
+
+	case "":
+		errs.Add("interactive.type", "interactive type is required")
+
+	default:
+		errs.Add("interactive.type", fmt.Sprintf("unsupported interactive type %q", c.Type))
+	}
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// validateReplyButtons checks the count, IDs and titles of reply buttons.
+func validateReplyButtons(errs *errors.ValidationErrors, buttons []InteractiveButton) {
+	if len(buttons) == 0 {
+		errs.Add("interactive.action.buttons", "at least one button is required")
+	}
+	if len(buttons) > MaxReplyButtons {
+		errs.Add("interactive.action.buttons", fmt.Sprintf("maximum %d buttons allowed, got %d", MaxReplyButtons, len(buttons)))
+	}
+
+	ids := make(map[string]bool)
+	titles := make(map[string]bool)
+	for i, button := range buttons {
+		field := fmt.Sprintf("interactive.action.buttons[%d].reply", i)
+		checkRequired(errs, field+".id", button.Reply.ID, MaxReplyButtonIDLength)
+		checkRequired(errs, field+".title", button.Reply.Title, MaxReplyButtonTitleLength)
+		if button.Reply.ID != "" && ids[button.Reply.ID] {
+			errs.Add(field+".id", fmt.Sprintf("duplicate button ID %q", button.Reply.ID))
+		}
+		if button.Reply.Title != "" && titles[button.Reply.Title] {
+			errs.Add(field+".title", fmt.Sprintf("duplicate button title %q", button.Reply.Title))
+		}
+		ids[button.Reply.ID] = true
+		titles[button.Reply.Title] = true
+	}
+}
+
+// validateListSections checks the sections and rows of a list message.
+func validateListSections(errs *errors.ValidationErrors, sections []InteractiveSection) {
+	if len(sections) == 0 {
+		errs.Add("interactive.action.sections", "at least one section is required")
+	}
+	if len(sections) > MaxListSections {
+		errs.Add("interactive.action.sections", fmt.Sprintf("maximum %d sections allowed, got %d", MaxListSections, len(sections)))
+	}
+
+	rows := 0
+	ids := make(map[string]bool)
+	for i, section := range sections {
+		field := fmt.Sprintf("interactive.action.sections[%d]", i)
+		if len(sections) > 1 {
+			checkRequired(errs, field+".title", section.Title, MaxSectionTitleLength)
+		} else {
+			checkLength(errs, field+".title", section.Title, MaxSectionTitleLength)
+		}
+		if len(section.Rows) == 0 {
+			errs.Add(field+".rows", "at least one row is required")
+		}
+		for j, row := range section.Rows {
+			rowField := fmt.Sprintf("%s.rows[%d]", field, j)
+			checkRequired(errs, rowField+".id", row.ID, MaxListRowIDLength)
+			checkRequired(errs, rowField+".title", row.Title, MaxListRowTitleLength)
+			checkLength(errs, rowField+".description", row.Description, MaxListRowDescLength)
+			if row.ID != "" && ids[row.ID] {
+				errs.Add(rowField+".id", fmt.Sprintf("duplicate row ID %q", row.ID))
+			}
+			ids[row.ID] = true
+		}
+		rows += len(section.Rows)
+	}
+	if rows > MaxListRows {
+		errs.Add("interactive.action.sections", fmt.Sprintf("maximum %d rows allowed across all sections, got %d", MaxListRows, rows))
+	}
+}
+
+// validateProductSections checks the sections and items of a product list.
+func validateProductSections(errs *errors.ValidationErrors, sections []InteractiveSection) {
+	if len(sections) == 0 {
+		errs.Add("interactive.action.sections", "at least one section is required")
+	}
+	if len(sections) > MaxProductListSections {
+		errs.Add("interactive.action.sections", fmt.Sprintf("maximum %d sections allowed, got %d", MaxProductListSections, len(sections)))
+	}
+
+	items := 0
+	for i, section := range sections {
+		field := fmt.Sprintf("interactive.action.sections[%d]", i)
+		checkRequired(errs, field+".title", section.Title, MaxSectionTitleLength)
+		if len(section.ProductItems) == 0 {
+			errs.Add(field+".product_items", "at least one product is required")
+		}
+		for j, item := range section.ProductItems {
+			if item.ProductRetailerID == "" {
+				errs.Add(fmt.Sprintf("%s.product_items[%d].product_retailer_id", field, j), "product retailer ID is required")
+			}
+		}
+		items += len(section.ProductItems)
+	}
+	if items > MaxProductListItems {
+		errs.Add("interactive.action.sections", fmt.Sprintf("maximum %d products allowed across all sections, got %d", MaxProductListItems, items))
+	}
+}
+
+// validateOrderDetails checks the required fields of an order_details
+// message and that its subtotal and total add up.
+func validateOrderDetails(errs *errors.ValidationErrors, params *ActionParameters) {
+	const field = "interactive.action.parameters"
+	if params == nil {
+		errs.Add(field, "order parameters are required")
+		return
+	}
+
+	before := len(*errs)
+	if params.ReferenceID == "" {
+		errs.Add(field+".reference_id", "reference ID is required")
+	}
+	if params.Currency == "" {
+		errs.Add(field+".currency", "currency is required")
+	}
+	if params.OrderType != "" && params.OrderType != OrderTypeDigitalGoods && params.OrderType != OrderTypePhysicalGoods {
+		errs.Add(field+".type", fmt.Sprintf("unknown order type %q", params.OrderType))
+	}
+	if params.TotalAmount == nil {
+		errs.Add(field+".total_amount", "total amount is required")
+	}
+	order := params.Order
+	if order == nil {
+		errs.Add(field+".order", "order is required")
+		return
+	}
+	if order.Status != OrderStatusPending {
+		errs.Add(field+".order.status", "order status must be pending for order details")
+	}
+	if len(order.Items) == 0 {
+		errs.Add(field+".order.items", "at least one item is required")
+	}
+	if order.Subtotal == nil {
+		errs.Add(field+".order.subtotal", "subtotal is required")
+	}
+	if len(*errs) > before {
+		return
+	}
+
+	// All amounts must share the total's offset to be compared
+	offset := params.TotalAmount.Offset
+	if offset <= 0 {
+		errs.Add(field+".total_amount.offset", "offset must be greater than zero")
+		return
+	}
+	checkOffset := func(name string, got int64) {
+		if got != offset {
+			errs.Add(field+"."+name, fmt.Sprintf("offset %d does not match the total amount offset %d", got, offset))
+		}
+	}
+
+	var subtotal int64
+	for i, item := range order.Items {
+		itemField := fmt.Sprintf("order.items[%d]", i)
+		if item.RetailerID == "" {
+			errs.Add(field+"."+itemField+".retailer_id", "retailer ID is required")
+		}
+		if item.Name == "" {
+			errs.Add(field+"."+itemField+".name", "name is required")
+		}
+		if item.Quantity <= 0 {
+			errs.Add(field+"."+itemField+".quantity", "quantity must be greater than zero")
+		}
+		price := item.Amount
+		if item.SaleAmount != nil {
+			price = *item.SaleAmount
+		}
+		checkOffset(itemField+".amount.offset", price.Offset)
+		itemTotal, ok := mulInt64(price.Value, int64(item.Quantity))
+		if ok {
+			subtotal, ok = addInt64(subtotal, itemTotal)
+		}
+		if !ok {
+			errs.Add(field+"."+itemField+".amount", "item total is out of range")
+			return
+		}
+	}
+
+	checkOffset("order.subtotal.offset", order.Subtotal.Offset)
+	if order.Subtotal.Value != subtotal {
+		errs.Add(field+".order.subtotal", fmt.Sprintf("subtotal %d does not match the sum of items %d", order.Subtotal.Value, subtotal))
+	}
+
+	total := order.Subtotal.Value
+	ok := true
+	if order.Tax != nil {
+		checkOffset("order.tax.offset", order.Tax.Offset)
+		total, ok = addInt64(total, order.Tax.Value)
+	}
+	if order.Shipping != nil && ok {
+		checkOffset("order.shipping.offset", order.Shipping.Offset)
+		total, ok = addInt64(total, order.Shipping.Value)
+	}
+	if order.Discount != nil && ok {
+		checkOffset("order.discount.offset", order.Discount.Offset)
+		total, ok = subInt64(total, order.Discount.Value)
+	}
+	if !ok {
+		errs.Add(field+".total_amount", "subtotal + tax + shipping - discount is out of range")
+		return
+	}
+	if params.TotalAmount.Value != total {
+		errs.Add(field+".total_amount", fmt.Sprintf("total amount %d does not match subtotal + tax + shipping - discount %d", params.TotalAmount.Value, total))
+	}
+}
+
+// validateOrderStatus checks the reference ID and status of an
+// order_status message.
+func validateOrderStatus(errs *errors.ValidationErrors, params *ActionParameters) {
+	const field = "interactive.action.parameters"
+	if params == nil {
+		errs.Add(field, "order status parameters are required")
+		return
+	}
+	if params.ReferenceID == "" {
+		errs.Add(field+".reference_id", "reference ID is required")
+	}
+	if params.Order == nil {
+		errs.Add(field+".order", "order is required")
+		return
+	}
+	switch params.Order.Status {
+	case OrderStatusPending, OrderStatusProcessing, OrderStatusPartiallyShipped,
+		OrderStatusShipped, OrderStatusCompleted, OrderStatusCanceled:
+	default:
+		errs.Add(field+".order.status", fmt.Sprintf("unknown order status %q", params.Order.Status))
+	}
+}
+
+// validateAddress checks the country and saved addresses of an address
+// message.
+func validateAddress(errs *errors.ValidationErrors, params *ActionParameters) {
+	const field = "interactive.action.parameters"
+	if params == nil {
+		errs.Add(field, "address parameters are required")
+		return
+	}
+	switch params.Country {
+	case AddressCountryIndia, AddressCountrySingapore:
+	case "":
+		errs.Add(field+".country", "country is required")
+	default:
+		errs.Add(field+".country", fmt.Sprintf("address messages are not supported in %q", params.Country))
+	}
+	for i, saved := range params.SavedAddresses {
+		if saved.ID == "" {
+			errs.Add(fmt.Sprintf("%s.saved_addresses[%d].id", field, i), "saved address ID is required")
+		}
+	}
+}
+
+// validateTemplateComponents checks the components of a template message
+// and the parameters they carry. Carousel cards are checked recursively.
+func validateTemplateComponents(errs *errors.ValidationErrors, field string, components []TemplateComponent) {
+	for i, component := range components {
+		componentField := fmt.Sprintf("%s[%d]", field, i)
+		switch component.Type {
+		case TemplateComponentHeader, TemplateComponentBody, TemplateComponentLimitedTimeOffer:
+		case TemplateComponentButton:
+			if component.SubType == "" {
+				errs.Add(componentField+".sub_type", "button sub type is required")
+			}
+			if component.Index == "" {
+				errs.Add(componentField+".index", "button index is required")
+			}
+		case TemplateComponentCarousel:
+			switch {
+			case len(component.Cards) == 0:
+				errs.Add(componentField+".cards", "at least one card is required")
+			case len(component.Cards) > MaxTemplateCarouselCards:
+				errs.Add(componentField+".cards", fmt.Sprintf("at most %d cards are allowed", MaxTemplateCarouselCards))
+			}
+			for j, card := range component.Cards {
+				validateTemplateComponents(errs, fmt.Sprintf("%s.cards[%d].components", componentField, j), card.Components)
+			}
+		case "":
+			errs.Add(componentField+".type", "component type is required")
+		default:
+			errs.Add(componentField+".type", fmt.Sprintf("unsupported component type %q", component.Type))
+		}
+
+		for j, param := range component.Parameters {
+			validateTemplateParameter(errs, fmt.Sprintf("%s.parameters[%d]", componentField, j), &param)
+		}
+	}
+}
+
+// validateTemplateParameter checks that a template parameter carries the
+// value its type requires.
+func validateTemplateParameter(errs *errors.ValidationErrors, field string, param *TemplateParameter) {
+	switch param.Type {
+	case TemplateParamText:
+		if param.Text == "" {
+			errs.Add(field+".text", "text is required")
+		}
+	case TemplateParamCurrency:
+		if param.Currency == nil || param.Currency.Code == "" {
+			errs.Add(field+".currency", "currency code is required")
+		}
+	case TemplateParamDateTime:
+		if param.DateTime == nil {
+			errs.Add(field+".date_time", "date time is required")
+		}
+	case TemplateParamImage, TemplateParamVideo:
+		media := param.Image
+		if param.Type == TemplateParamVideo {
+			media = param.Video
+		}
+		mediaField := field + "." + string(param.Type)
+		if media == nil {
+			errs.Add(mediaField, string(param.Type)+" content is required")
+		} else {
+			validateSource(errs, mediaField, media.ID, media.Link, media.Path)
+		}
+	case TemplateParamDocument:
+		if param.Document == nil {
+			errs.Add(field+".document", "document content is required")
+		} else {
+			validateSource(errs, field+".document", param.Document.ID, param.Document.Link, param.Document.Path)
+		}
+	case TemplateParamPayload:
+		if param.Payload == "" {
+			errs.Add(field+".payload", "payload is required")
+		}
+	case TemplateParamLocation:
+		if param.Location == nil {
+			errs.Add(field+".location", "location content is required")
+		}
+	case TemplateParamCouponCode:
+		if param.CouponCode == "" {
+			errs.Add(field+".coupon_code", "coupon code is required")
+		}
+	case TemplateParamLimitedTimeOffer:
+		if param.LimitedTimeOffer == nil {
+			errs.Add(field+".limited_time_offer", "limited time offer is required")
+		}
+	case TemplateParamAction:
+		if param.Action == nil {
+			errs.Add(field+".action", "action is required")
+		}
+	case "":
+		errs.Add(field+".type", "parameter type is required")
+	default:
+		errs.Add(field+".type", fmt.Sprintf("unsupported parameter type %q", param.Type))
+	}
+}
+
+// validateMedia checks image, video, audio and sticker content.
+func validateMedia(errs *errors.ValidationErrors, field string, media *MediaContent, captions bool) {
+	if media == nil {
+		errs.Add(field, field+" content is required")
+		return
+	}
+	validateSource(errs, field, media.ID, media.Link, media.Path)
+	if captions {
+		checkLength(errs, field+".caption", media.Caption, MaxCaptionLength)
+	} else if media.Caption != "" {
+		errs.Add(field+".caption", field+" messages do not support captions")
+	}
+}
+
+// validateSource checks that media is given by exactly one of ID, Link or
+// a local Path to upload.
+func validateSource(errs *errors.ValidationErrors, field, id, link, path string) {
+	set := 0
+	for _, source := range []string{id, link, path} {
+		if source != "" {
+			set++
+		}
+	}
+
+	switch {
+	case set == 0:
+		errs.Add(field, "either ID, Link or Path is required")
+	case set > 1:
+		errs.Add(field, "only one of ID, Link or Path may be set")
+	}
+}
+
+// checkRequired checks that value is set and at most max characters long.
+func checkRequired(errs *errors.ValidationErrors, field, value string, max int) {
+	if value == "" {
+		errs.Add(field, "value is required")
+		return
+	}
+	checkLength(errs, field, value, max)
+}
+
+// checkLength checks that value is at most max characters long.
+func checkLength(errs *errors.ValidationErrors, field, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		errs.Add(field, fmt.Sprintf("must be %d characters or less, got %d", max, n))
+	}
+}
@@ -0,0 +1,255 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+)
+
+// orderParams returns valid order_details parameters: two items at 10.00
+// and one at 5.00 on sale for 4.00, plus tax and shipping, minus a discount.
+func orderParams() *ActionParameters {
+	return &ActionParameters{
+		ReferenceID: "ref-1",
+		Currency:    "INR",
+		TotalAmount: &Amount{Value: 2500, Offset: 100},
+		Order: &OrderDetails{
+			Status: OrderStatusPending,
+			Items: []OrderItem{
+				{RetailerID: "a", Name: "Tea", Amount: Amount{Value: 1000, Offset: 100}, Quantity: 2},
+				{RetailerID: "b", Name: "Cup", Amount: Amount{Value: 500, Offset: 100}, SaleAmount: &Amount{Value: 400, Offset: 100}, Quantity: 1},
+			},
+			Subtotal: &Amount{Value: 2400, Offset: 100},
+			Tax:      &OrderCharge{Value: 200, Offset: 100},
+			Shipping: &OrderCharge{Value: 100, Offset: 100},
+			Discount: &OrderCharge{Value: 200, Offset: 100},
+		},
+	}
+}
+
+func TestValidateOrderDetails(t *testing.T) {
+	const field = "interactive.action.parameters"
+
+	tests := []struct {
+		name       string
+		modify     func(p *ActionParameters)
+		wantFields []string
+	}{
+		{"valid", func(p *ActionParameters) {}, nil},
+		{"no charges", func(p *ActionParameters) {
+			p.Order.Tax, p.Order.Shipping, p.Order.Discount = nil, nil, nil
+			p.TotalAmount.Value = 2400
+		}, nil},
+		{"required fields", func(p *ActionParameters) {
+			p.ReferenceID, p.Currency, p.TotalAmount = "", "", nil
+			p.Order.Items, p.Order.Subtotal = nil, nil
+		}, []string{field + ".reference_id", field + ".currency", field + ".total_amount", field + ".order.items", field + ".order.subtotal"}},
+		{"not pending", func(p *ActionParameters) { p.Order.Status = OrderStatusShipped }, []string{field + ".order.status"}},
+		{"subtotal ignores sale price", func(p *ActionParameters) {
+			p.Order.Subtotal.Value = 2500
+			p.TotalAmount.Value = 2600
+		}, []string{field + ".order.subtotal"}},
+		{"total without discount", func(p *ActionParameters) { p.TotalAmount.Value = 2700 }, []string{field + ".total_amount"}},
+		{"mismatched offsets", func(p *ActionParameters) {
+			p.Order.Items[0].Amount = Amount{Value: 10000, Offset: 1000}
+			p.Order.Tax.Offset = 1000
+		}, []string{field + ".order.items[0].amount.offset", field + ".order.subtotal", field + ".order.tax.offset"}},
+		{"item overflow", func(p *ActionParameters) {
+			p.Order.Items[0].Amount.Value = math.MaxInt64/2 + 1
+		}, []string{field + ".order.items[0].amount"}},
+		{"total overflow", func(p *ActionParameters) {
+			p.Order.Items = p.Order.Items[:1]
+			p.Order.Items[0] = OrderItem{RetailerID: "a", Name: "Tea", Amount: Amount{Value: math.MaxInt64, Offset: 100}, Quantity: 1}
+			p.Order.Subtotal.Value = math.MaxInt64
+		}, []string{field + ".total_amount"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := orderParams()
+			tt.modify(params)
+
+			var errs errors.ValidationErrors
+			validateOrderDetails(&errs, params)
+
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %q, want %q\n%v", fields, tt.wantFields, errs)
+			}
+		})
+	}
+}
+
+func TestMessageRequestValidate(t *testing.T) {
+	const to = "15551234567"
+	text := func(n int) string { return strings.Repeat("a", n) }
+	buttons := func(titles ...string) *MessageRequest {
+		req := &MessageRequest{To: to, Type: MessageTypeInteractive, Interactive: &InteractiveContent{
+			Type: InteractiveTypeButton,
+			Body: InteractiveBody{Text: "Pick one"},
+		}}
+		for i, title := range titles {
+			req.Interactive.Action.Buttons = append(req.Interactive.Action.Buttons,
+				InteractiveButton{Type: "reply", Reply: InteractiveReply{ID: fmt.Sprintf("b%d", i), Title: title}})
+		}
+		return req
+	}
+	list := func(rowsPerSection ...int) *MessageRequest {
+		req := &MessageRequest{To: to, Type: MessageTypeInteractive, Interactive: &InteractiveContent{
+			Type:   InteractiveTypeList,
+			Body:   InteractiveBody{Text: "Pick a slot"},
+			Action: InteractiveAction{Button: "Slots"},
+		}}
+		id := 0
+		for i, n := range rowsPerSection {
+			section := InteractiveSection{Title: fmt.Sprintf("Section %d", i)}
+			for j := 0; j < n; j++ {
+				section.Rows = append(section.Rows, InteractiveRow{ID: fmt.Sprintf("r%d", id), Title: "Row"})
+				id++
+			}
+			req.Interactive.Action.Sections = append(req.Interactive.Action.Sections, section)
+		}
+		return req
+	}
+	carousel := func(cards int) *MessageRequest {
+		component := TemplateComponent{Type: TemplateComponentCarousel}
+		for i := 0; i < cards; i++ {
+			component.Cards = append(component.Cards, TemplateCard{CardIndex: i, Components: []TemplateComponent{
+				{Type: TemplateComponentHeader, Parameters: []TemplateParameter{{Type: TemplateParamImage, Image: &MediaContent{ID: "1"}}}},
+			}})
+		}
+		return &MessageRequest{To: to, Type: MessageTypeTemplate, Template: &TemplateContent{
+			Name: "picks", Language: TemplateLanguage{Code: "en_US"}, Components: []TemplateComponent{component},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		req        *MessageRequest
+		modify     func(r *MessageRequest)
+		wantFields []string
+	}{
+		// Text
+		{"text at limit", &MessageRequest{To: to, Type: MessageTypeText, Text: &TextContent{Body: text(MaxTextBodyLength)}}, nil, nil},
+		{"text too long", &MessageRequest{To: to, Type: MessageTypeText, Text: &TextContent{Body: text(MaxTextBodyLength + 1)}}, nil, []string{"text.body"}},
+		{"text counts characters", &MessageRequest{To: to, Type: MessageTypeText, Text: &TextContent{Body: strings.Repeat("é", MaxTextBodyLength)}}, nil, nil},
+		{"empty text", &MessageRequest{To: to, Type: MessageTypeText, Text: &TextContent{}}, nil, []string{"text.body"}},
+		{"no recipient", &MessageRequest{Type: MessageTypeText, Text: &TextContent{Body: "hi"}}, nil, []string{"to"}},
+
+		// Media
+		{"caption at limit", &MessageRequest{To: to, Type: MessageTypeImage, Image: &MediaContent{ID: "1", Caption: text(MaxCaptionLength)}}, nil, nil},
+		{"caption too long", &MessageRequest{To: to, Type: MessageTypeImage, Image: &MediaContent{ID: "1", Caption: text(MaxCaptionLength + 1)}}, nil, []string{"image.caption"}},
+		{"document caption too long", &MessageRequest{To: to, Type: MessageTypeDocument, Document: &DocumentContent{Link: "https://example.com/a.pdf", Caption: text(MaxCaptionLength + 1)}}, nil, []string{"document.caption"}},
+		{"local path", &MessageRequest{To: to, Type: MessageTypeVideo, Video: &MediaContent{Path: "clip.mp4"}}, nil, nil},
+		{"no source", &MessageRequest{To: to, Type: MessageTypeImage, Image: &MediaContent{}}, nil, []string{"image"}},
+		{"ID and link", &MessageRequest{To: to, Type: MessageTypeImage, Image: &MediaContent{ID: "1", Link: "https://example.com/a.jpg"}}, nil, []string{"image"}},
+		{"path and link", &MessageRequest{To: to, Type: MessageTypeAudio, Audio: &MediaContent{Path: "a.ogg", Link: "https://example.com/a.ogg"}}, nil, []string{"audio"}},
+		{"path and ID", &MessageRequest{To: to, Type: MessageTypeDocument, Document: &DocumentContent{Path: "a.pdf", ID: "1"}}, nil, []string{"document"}},
+
+		// Reply buttons
+		{"three buttons", buttons("Yes", "No", text(MaxReplyButtonTitleLength)), nil, nil},
+		{"four buttons", buttons("A", "B", "C", "D"), nil, []string{"interactive.action.buttons"}},
+		{"button title too long", buttons("Yes", text(MaxReplyButtonTitleLength+1)), nil, []string{"interactive.action.buttons[1].reply.title"}},
+		{"duplicate button IDs", buttons("Yes", "No"), func(r *MessageRequest) {
+			r.Interactive.Action.Buttons[1].Reply.ID = "b0"
+		}, []string{"interactive.action.buttons[1].reply.id"}},
+		{"duplicate button titles", buttons("Yes", "Yes"), nil, []string{"interactive.action.buttons[1].reply.title"}},
+		{"body too long", buttons("Yes"), func(r *MessageRequest) {
+			r.Interactive.Body.Text = text(MaxInteractiveBodyLength + 1)
+		}, []string{"interactive.body.text"}},
+
+		// Lists
+		{"ten rows", list(4, 6), nil, nil},
+		{"eleven rows", list(5, 6), nil, []string{"interactive.action.sections"}},
+		{"row title too long", list(2), func(r *MessageRequest) {
+			r.Interactive.Action.Sections[0].Rows[1].Title = text(MaxListRowTitleLength + 1)
+		}, []string{"interactive.action.sections[0].rows[1].title"}},
+		{"row title at limit", list(1), func(r *MessageRequest) {
+			r.Interactive.Action.Sections[0].Rows[0].Title = text(MaxListRowTitleLength)
+		}, nil},
+		{"duplicate row IDs", list(1, 1), func(r *MessageRequest) {
+			r.Interactive.Action.Sections[1].Rows[0].ID = "r0"
+		}, []string{"interactive.action.sections[1].rows[0].id"}},
+		{"untitled section among several", list(1, 1), func(r *MessageRequest) {
+			r.Interactive.Action.Sections[0].Title = ""
+		}, []string{"interactive.action.sections[0].title"}},
+		{"list button too long", list(1), func(r *MessageRequest) {
+			r.Interactive.Action.Button = text(MaxListButtonLength + 1)
+		}, []string{"interactive.action.button"}},
+
+		// Templates
+		{"ten cards", carousel(MaxTemplateCarouselCards), nil, nil},
+		{"eleven cards", carousel(MaxTemplateCarouselCards + 1), nil, []string{"template.components[0].cards"}},
+		{"card media with two sources", carousel(2), func(r *MessageRequest) {
+			r.Template.Components[0].Cards[1].Components[0].Parameters[0].Image.Path = "a.jpg"
+		}, []string{"template.components[0].cards[1].components[0].parameters[0].image"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.modify != nil {
+				tt.modify(tt.req)
+			}
+
+			var fields []string
+			if err := tt.req.Validate(); err != nil {
+				for _, e := range err.(errors.ValidationErrors) {
+					fields = append(fields, e.Field)
+				}
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("error fields = %q, want %q", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllViolations(t *testing.T) {
+	req := &MessageRequest{
+		Type:    MessageTypeInteractive,
+		Context: &Context{},
+		Interactive: &InteractiveContent{
+			Type:   InteractiveTypeButton,
+			Header: &InteractiveHeader{Type: "text", Text: strings.Repeat("h", MaxInteractiveHeaderLength+1)},
+			Body:   InteractiveBody{Text: strings.Repeat("b", MaxInteractiveBodyLength+1)},
+			Footer: &InteractiveFooter{Text: strings.Repeat("f", MaxInteractiveFooterLength+1)},
+			Action: InteractiveAction{Buttons: []InteractiveButton{
+				{Type: "reply", Reply: InteractiveReply{ID: "a", Title: "A"}},
+				{Type: "reply", Reply: InteractiveReply{ID: "a", Title: "B"}},
+				{Type: "reply", Reply: InteractiveReply{ID: "c", Title: strings.Repeat("t", MaxReplyButtonTitleLength+1)}},
+				{Type: "reply", Reply: InteractiveReply{ID: "d", Title: "D"}},
+			}},
+		},
+	}
+
+	err := req.Validate()
+	errs, ok := err.(errors.ValidationErrors)
+	if !ok {
+		t.Fatalf("Validate = %v, want ValidationErrors", err)
+	}
+
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	want := []string{
+		"to",
+		"context.message_id",
+		"interactive.header.text",
+		"interactive.body.text",
+		"interactive.footer.text",
+		"interactive.action.buttons",
+		"interactive.action.buttons[1].reply.id",
+		"interactive.action.buttons[2].reply.title",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("error fields = %q, want %q", fields, want)
+	}
+}
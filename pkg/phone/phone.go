@@ -0,0 +1,176 @@
+// Package phone provides parsing and normalization of phone numbers to the
+// format the WhatsApp API expects: the country calling code followed by the
+// national number, digits only.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Parse errors. Errors returned by Parse and Normalize wrap one of these.
+var (
+	ErrEmpty              = errors.New("phone number is empty")
+	ErrInvalidCharacters  = errors.New("phone number contains invalid characters")
+	ErrUnknownCountryCode = errors.New("unknown country calling code")
+	ErrUnsupportedRegion  = errors.New("unsupported region")
+	ErrTooShort           = errors.New("phone number is too short")
+	ErrTooLong            = errors.New("phone number is too long")
+)
+
+// Number is a parsed phone number.
+type Number struct {
+	// CountryCode is the country calling code, e.g. "44".
+	CountryCode string
+	// National is the national significant number, without trunk prefix.
+	National string
+}
+
+// String returns the number in WhatsApp format, e.g. "447911123456".
+func (n Number) String() string {
+	return n.CountryCode + n.National
+}
+
+// E164 returns the number in E.164 format, e.g. "+447911123456".
+func (n Number) E164() string {
+	return "+" + n.String()
+}
+
+// Normalize parses number and returns it in WhatsApp format. See Parse.
+func Normalize(number, region string) (string, error) {
+	n, err := Parse(number, region)
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// Parse parses a phone number. Spaces, dashes, dots, slashes and
+// parentheses are ignored. Numbers starting with "+" or an international
+// call prefix ("00", or "011" in North America) are parsed as
+// international. Other numbers are parsed as national numbers of region,
+// an ISO 3166-1 alpha-2 code such as "US" or "GB", with the trunk prefix
+// removed. Digits that already start with the region's calling code, such
+// as a wa_id from a webhook, are kept as is. With an empty region every
+// number must include its country calling code.
+func Parse(number, region string) (Number, error) {
+	digits, international, err := clean(number)
+	if err != nil {
+		return Number{}, err
+	}
+
+	var info regionInfo
+	if region != "" {
+		var ok bool
+		info, ok = regions[strings.ToUpper(region)]
+		if !ok {
+			return Number{}, fmt.Errorf("%w: %q", ErrUnsupportedRegion, region)
+		}
+	}
+
+	if !international {
+		switch {
+		case strings.HasPrefix(digits, "00"):
+			digits, international = digits[2:], true
+		case info.code == "1" && strings.HasPrefix(digits, "011"):
+			digits, international = digits[3:], true
+		}
+	}
+	if international || region == "" {
+		return parseInternational(digits)
+	}
+
+	if info.trunk != "" && strings.HasPrefix(digits, info.trunk) {
+		return newNumber(info.code, digits[len(info.trunk):])
+	}
+	if strings.HasPrefix(digits, info.code) {
+		if n, err := parseInternational(digits); err == nil && n.CountryCode == info.code {
+			// Without a trunk prefix to tell them apart, only treat the
+			// digits as international if they can't be a national number
+			if info.trunk != "" || validLength(info.code, digits) != nil {
+				return n, nil
+			}
+		}
+	}
+
+	return newNumber(info.code, digits)
+}
+
+// IsValid reports whether number can be parsed for region.
+func IsValid(number, region string) bool {
+	_, err := Parse(number, region)
+	return err == nil
+}
+
+// CountryCodeForRegion returns the calling code of region, or "" if the
+// region is not supported.
+func CountryCodeForRegion(region string) string {
+	return regions[strings.ToUpper(region)].code
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// clean strips formatting characters from number and reports whether it
+// started with "+".
+func clean(number string) (digits string, international bool, err error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return "", false, ErrEmpty
+	}
+	if strings.HasPrefix(number, "+") {
+		number, international = number[1:], true
+	}
+
+	var b strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '/' || r == '(' || r == ')':
+		default:
+			return "", false, fmt.Errorf("%w: %q", ErrInvalidCharacters, r)
+		}
+	}
+	if b.Len() == 0 {
+		return "", false, ErrEmpty
+	}
+
+	return b.String(), international, nil
+}
+
+// parseInternational splits digits into calling code and national number.
+// Calling codes are prefix-free, so at most one of the candidates matches.
+func parseInternational(digits string) (Number, error) {
+	for size := 1; size <= 3 && size < len(digits); size++ {
+		if _, ok := nationalLengths[digits[:size]]; ok {
+			return newNumber(digits[:size], digits[size:])
+		}
+	}
+	return Number{}, fmt.Errorf("%w: +%s", ErrUnknownCountryCode, digits)
+}
+
+// newNumber validates the national number length for code.
+func newNumber(code, national string) (Number, error) {
+	if err := validLength(code, national); err != nil {
+		return Number{}, err
+	}
+	return Number{CountryCode: code, National: national}, nil
+}
+
+// validLength checks the length of a national number for code.
+func validLength(code, national string) error {
+	lengths, ok := nationalLengths[code]
+	if !ok {
+		return fmt.Errorf("%w: +%s", ErrUnknownCountryCode, code)
+	}
+	if len(national) < lengths[0] {
+		return fmt.Errorf("%w: +%s numbers have at least %d digits after the country code", ErrTooShort, code, lengths[0])
+	}
+	if len(national) > lengths[1] {
+		return fmt.Errorf("%w: +%s numbers have at most %d digits after the country code", ErrTooLong, code, lengths[1])
+	}
+	return nil
+}
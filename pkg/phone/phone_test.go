@@ -0,0 +1,78 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		region  string
+		want    string
+		wantErr error
+	}{
+		// International input
+		{"plus prefix", "+44 7911 123456", "", "447911123456", nil},
+		{"plus prefix ignores region", "+44 7911 123456", "US", "447911123456", nil},
+		{"00 prefix", "0044 7911 123456", "DE", "447911123456", nil},
+		{"011 prefix in North America", "011 44 7911 123456", "US", "447911123456", nil},
+		{"digits without region", "12025550123", "", "12025550123", nil},
+
+		// National input
+		{"national with trunk prefix", "07911 123456", "GB", "447911123456", nil},
+		{"national with formatting", "(202) 555-0123", "US", "12025550123", nil},
+		{"national with NANP trunk prefix", "1 202 555 0123", "US", "12025550123", nil},
+		{"national without trunk prefix", "55 1234 5678", "MX", "525512345678", nil},
+		{"brazil mobile with trunk prefix", "0 11 98765 4321", "BR", "5511987654321", nil},
+
+		// wa_ids as received in webhooks
+		{"wa_id with region", "447911123456", "GB", "447911123456", nil},
+		{"brazil wa_id", "5511987654321", "BR", "5511987654321", nil},
+		{"mexico wa_id", "525512345678", "", "525512345678", nil},
+		{"legacy mexico wa_id", "5215512345678", "", "5215512345678", nil},
+		{"legacy mexico wa_id with region", "5215512345678", "MX", "5215512345678", nil},
+
+		// Errors
+		{"empty", "  ", "US", "", ErrEmpty},
+		{"only formatting", "()-", "US", "", ErrEmpty},
+		{"letters", "+1 202 CALL NOW", "", "", ErrInvalidCharacters},
+		{"unknown region", "2025550123", "XX", "", ErrUnsupportedRegion},
+		{"unknown country code", "+999 123456", "", "", ErrUnknownCountryCode},
+		{"too short", "+44 12", "", "", ErrTooShort},
+		{"too long", "+1 202 555 01234", "", "", ErrTooLong},
+		{"national too short", "555 012", "US", "", ErrTooShort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.number, tt.region)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q, %q) error = %v, want %v", tt.number, tt.region, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %q) error = %v", tt.number, tt.region, err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("Parse(%q, %q) = %s, want %s", tt.number, tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberFormats(t *testing.T) {
+	n, err := Parse("07911 123456", "GB")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if n.CountryCode != "44" || n.National != "7911123456" {
+		t.Fatalf("Parse = %+v, want country code 44 and national 7911123456", n)
+	}
+	if got := n.E164(); got != "+447911123456" {
+		t.Fatalf("E164() = %s, want +447911123456", got)
+	}
+}
@@ -0,0 +1,118 @@
+package phone
+
+// regionInfo describes how national numbers of a region are written.
+type regionInfo struct {
+	code  string // country calling code
+	trunk string // trunk prefix dialled before national numbers, if any
+}
+
+// regions maps ISO 3166-1 alpha-2 region codes to their numbering plan.
+var regions = map[string]regionInfo{
+	// North American Numbering Plan
+	"US": {"1", "1"}, "CA": {"1", "1"}, "PR": {"1", "1"}, "DO": {"1", "1"},
+	"JM": {"1", "1"}, "TT": {"1", "1"}, "BS": {"1", "1"}, "BB": {"1", "1"},
+
+	// Europe
+	"GB": {"44", "0"}, "IE": {"353", "0"}, "FR": {"33", "0"}, "DE": {"49", "0"},
+	"NL": {"31", "0"}, "BE": {"32", "0"}, "LU": {"352", ""}, "CH": {"41", "0"},
+	"AT": {"43", "0"}, "IT": {"39", ""}, "ES": {"34", ""}, "PT": {"351", ""},
+	"GR": {"30", ""}, "DK": {"45", ""}, "NO": {"47", ""}, "SE": {"46", "0"},
+	"FI": {"358", "0"}, "IS": {"354", ""}, "PL": {"48", ""}, "CZ": {"420", ""},
+	"SK": {"421", "0"}, "HU": {"36", "06"}, "RO": {"40", "0"}, "BG": {"359", "0"},
+	"HR": {"385", "0"}, "SI": {"386", "0"}, "RS": {"381", "0"}, "BA": {"387", "0"},
+	"ME": {"382", "0"}, "MK": {"389", "0"}, "AL": {"355", "0"}, "XK": {"383", "0"},
+	"MT": {"356", ""}, "CY": {"357", ""}, "EE": {"372", ""}, "LV": {"371", ""},
+	"LT": {"370", "0"}, "UA": {"380", "0"}, "BY": {"375", "8"}, "MD": {"373", "0"},
+	"RU": {"7", "8"}, "KZ": {"7", "8"}, "GE": {"995", "0"}, "AM": {"374", "0"},
+	"AZ": {"994", "0"}, "TR": {"90", "0"}, "MC": {"377", "0"}, "AD": {"376", ""},
+	"SM": {"378", ""}, "LI": {"423", ""}, "GI": {"350", ""},
+
+	// Middle East
+	"IL": {"972", "0"}, "PS": {"970", "0"}, "JO": {"962", "0"}, "LB": {"961", "0"},
+	"SY": {"963", "0"}, "IQ": {"964", "0"}, "SA": {"966", "0"}, "AE": {"971", "0"},
+	"QA": {"974", ""}, "BH": {"973", ""}, "KW": {"965", ""}, "OM": {"968", ""},
+	"YE": {"967", "0"}, "IR": {"98", "0"},
+
+	// Asia and Pacific
+	"IN": {"91", "0"}, "PK": {"92", "0"}, "BD": {"880", "0"}, "LK": {"94", "0"},
+	"NP": {"977", "0"}, "AF": {"93", "0"}, "CN": {"86", "0"}, "HK": {"852", ""},
+	"MO": {"853", ""}, "TW": {"886", "0"}, "JP": {"81", "0"}, "KR": {"82", "0"},
+	"MN": {"976", "0"}, "SG": {"65", ""}, "MY": {"60", "0"}, "ID": {"62", "0"},
+	"PH": {"63", "0"}, "TH": {"66", "0"}, "VN": {"84", "0"}, "KH": {"855", "0"},
+	"LA": {"856", "0"}, "MM": {"95", "0"}, "BN": {"673", ""}, "MV": {"960", ""},
+	"UZ": {"998", ""}, "KG": {"996", "0"}, "TJ": {"992", ""}, "TM": {"993", "8"},
+	"AU": {"61", "0"}, "NZ": {"64", "0"}, "FJ": {"679", ""}, "PG": {"675", ""},
+
+	// Latin America
+	"MX": {"52", ""}, "GT": {"502", ""}, "SV": {"503", ""}, "HN": {"504", ""},
+	"NI": {"505", ""}, "CR": {"506", ""}, "PA": {"507", ""}, "CU": {"53", "0"},
+	"HT": {"509", ""}, "CO": {"57", ""}, "VE": {"58", "0"}, "EC": {"593", "0"},
+	"PE": {"51", "0"}, "BO": {"591", "0"}, "CL": {"56", ""}, "AR": {"54", "0"},
+	"UY": {"598", "0"}, "PY": {"595", "0"}, "BR": {"55", "0"},
+
+	// Africa
+	"EG": {"20", "0"}, "MA": {"212", "0"}, "DZ": {"213", "0"}, "TN": {"216", ""},
+	"LY": {"218", "0"}, "SD": {"249", "0"}, "ET": {"251", "0"}, "KE": {"254", "0"},
+	"UG": {"256", "0"}, "TZ": {"255", "0"}, "RW": {"250", ""}, "NG": {"234", "0"},
+	"GH": {"233", "0"}, "CI": {"225", ""}, "SN": {"221", ""}, "CM": {"237", ""},
+	"CD": {"243", "0"}, "AO": {"244", ""}, "ZM": {"260", "0"}, "ZW": {"263", "0"},
+	"MZ": {"258", ""}, "MW": {"265", "0"}, "BW": {"267", ""}, "NA": {"264", "0"},
+	"ZA": {"27", "0"}, "MU": {"230", ""}, "MG": {"261", "0"},
+}
+
+// nationalLengths maps country calling codes to the minimum and maximum
+// length of their national significant numbers. +52 allows 11 digits for
+// the legacy mobile prefix 1, which Mexican wa_ids still carry.
+var nationalLengths = map[string][2]int{
+	"1": {10, 10}, "7": {10, 10},
+
+	"20": {8, 10}, "27": {9, 9}, "30": {10, 10}, "31": {9, 9}, "32": {8, 9},
+	"33": {9, 9}, "34": {9, 9}, "36": {8, 9}, "39": {6, 11}, "40": {9, 9},
+	"41": {9, 9}, "43": {4, 13}, "44": {7, 10}, "45": {8, 8}, "46": {7, 10},
+	"47": {8, 8}, "48": {9, 9}, "49": {5, 15}, "51": {8, 9}, "52": {10, 11},
+	"53": {6, 8}, "54": {10, 11}, "55": {10, 11}, "56": {9, 9}, "57": {8, 10},
+	"58": {10, 10}, "60": {8, 10}, "61": {9, 9}, "62": {8, 12}, "63": {8, 10},
+	"64": {8, 10}, "65": {8, 8}, "66": {8, 9}, "81": {9, 10}, "82": {8, 10},
+	"84": {9, 10}, "86": {9, 12}, "90": {10, 10}, "91": {10, 10}, "92": {9, 10},
+	"93": {9, 9}, "94": {9, 9}, "95": {7, 10}, "98": {10, 10},
+
+	"211": {9, 9}, "212": {9, 9}, "213": {8, 9}, "216": {8, 8}, "218": {9, 9},
+	"220": {7, 7}, "221": {9, 9}, "222": {8, 8}, "223": {8, 8}, "224": {8, 9},
+	"225": {8, 10}, "226": {8, 8}, "227": {8, 8}, "228": {8, 8}, "229": {8, 10},
+	"230": {7, 8}, "231": {7, 9}, "232": {8, 8}, "233": {9, 9}, "234": {8, 10},
+	"235": {8, 8}, "236": {8, 8}, "237": {9, 9}, "238": {7, 7}, "239": {7, 7},
+	"240": {9, 9}, "241": {7, 8}, "242": {9, 9}, "243": {9, 9}, "244": {9, 9},
+	"245": {7, 9}, "246": {7, 7}, "248": {7, 7}, "249": {9, 9}, "250": {9, 9},
+	"251": {9, 9}, "252": {7, 9}, "253": {8, 8}, "254": {9, 10}, "255": {9, 9},
+	"256": {9, 9}, "257": {8, 8}, "258": {8, 9}, "260": {9, 9}, "261": {9, 9},
+	"262": {9, 9}, "263": {9, 9}, "264": {8, 9}, "265": {7, 9}, "266": {8, 8},
+	"267": {7, 8}, "268": {8, 8}, "269": {7, 7}, "290": {4, 5}, "291": {7, 7},
+	"297": {7, 7}, "298": {6, 6}, "299": {6, 6},
+
+	"350": {8, 8}, "351": {9, 9}, "352": {4, 11}, "353": {7, 9}, "354": {7, 9},
+	"355": {8, 9}, "356": {8, 8}, "357": {8, 8}, "358": {5, 12}, "359": {7, 9},
+	"370": {8, 8}, "371": {8, 8}, "372": {7, 8}, "373": {8, 8}, "374": {8, 8},
+	"375": {9, 10}, "376": {6, 9}, "377": {8, 9}, "378": {6, 10}, "380": {9, 9},
+	"381": {8, 12}, "382": {8, 8}, "383": {8, 9}, "385": {8, 9}, "386": {8, 8},
+	"387": {8, 9}, "389": {8, 8}, "420": {9, 9}, "421": {9, 9}, "423": {7, 9},
+
+	"500": {5, 5}, "501": {7, 7}, "502": {8, 8}, "503": {8, 8}, "504": {8, 8},
+	"505": {8, 8}, "506": {8, 8}, "507": {7, 8}, "508": {6, 6}, "509": {8, 8},
+	"590": {9, 9}, "591": {8, 8}, "592": {7, 7}, "593": {8, 9}, "594": {9, 9},
+	"595": {9, 9}, "596": {9, 9}, "597": {6, 7}, "598": {8, 8}, "599": {7, 8},
+
+	"670": {7, 8}, "672": {6, 6}, "673": {7, 7}, "674": {7, 7}, "675": {7, 8},
+	"676": {5, 7}, "677": {5, 7}, "678": {5, 7}, "679": {7, 7}, "680": {7, 7},
+	"681": {6, 6}, "682": {5, 5}, "683": {4, 7}, "685": {5, 7}, "686": {5, 8},
+	"687": {6, 6}, "688": {5, 7}, "689": {6, 8}, "690": {4, 7}, "691": {7, 7},
+	"692": {7, 7},
+
+	"850": {8, 10}, "852": {8, 8}, "853": {8, 8}, "855": {8, 9}, "856": {8, 10},
+	"880": {8, 10}, "886": {8, 9},
+
+	"960": {7, 7}, "961": {7, 8}, "962": {8, 9}, "963": {8, 9}, "964": {8, 10},
+	"965": {7, 8}, "966": {9, 9}, "967": {7, 9}, "968": {8, 8}, "970": {8, 9},
+	"971": {8, 9}, "972": {8, 9}, "973": {8, 8}, "974": {7, 8}, "975": {7, 8},
+	"976": {8, 8}, "977": {8, 10}, "992": {9, 9}, "993": {8, 8}, "994": {9, 9},
+	"995": {9, 9}, "996": {9, 9}, "998": {9, 9},
+}
@@ -0,0 +1,569 @@
+// Package preview renders messages locally, showing what the recipient will
+// see without calling the API. Previews are useful in tests and dry runs.
+package preview
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// Preview is a structured rendering of a message.
+type Preview struct {
+	Type models.MessageType
+	To   string
+
+	Header string // Header text, or a media marker such as "[image: <id>]"
+	Body   string
+	Footer string
+
+	Buttons    []Button
+	ListButton string // Text of the button that opens a list
+	Sections   []Section
+
+	Offer string     // Limited-time offer text and expiration
+	Cards []*Preview // Carousel cards
+}
+
+// Button is a rendered button.
+type Button struct {
+	Type  string // reply, url, phone_number, quick_reply, ...
+	Text  string
+	Value string // Reply ID, URL, phone number or payload
+}
+
+// Section is a rendered list section.
+type Section struct {
+	Title string
+	Rows  []Row
+}
+
+// Row is a rendered list row.
+type Row struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// Text returns the preview as plain text.
+func (p *Preview) Text() string {
+	var parts []string
+
+	if p.Header != "" {
+		parts = append(parts, p.Header)
+	}
+	if p.Offer != "" {
+		parts = append(parts, "[offer] "+p.Offer)
+	}
+	if p.Body != "" {
+		parts = append(parts, p.Body)
+	}
+	if p.Footer != "" {
+		parts = append(parts, p.Footer)
+	}
+
+	if len(p.Buttons) > 0 {
+		lines := make([]string, len(p.Buttons))
+		for i, btn := range p.Buttons {
+			lines[i] = "[" + btn.Text + "]"
+			if btn.Value != "" && btn.Type != "reply" && btn.Type != "quick_reply" {
+				lines[i] += " " + btn.Value
+			}
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+
+	if p.ListButton != "" || len(p.Sections) > 0 {
+		var lines []string
+		lines = append(lines, "["+p.ListButton+"]")
+		for _, section := range p.Sections {
+			if section.Title != "" {
+				lines = append(lines, section.Title)
+			}
+			for _, row := range section.Rows {
+				line := "  - " + row.Title
+				if row.Description != "" {
+					line += ": " + row.Description
+				}
+				lines = append(lines, line)
+			}
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+
+	for i, card := range p.Cards {
+		parts = append(parts, fmt.Sprintf("--- card %d ---\n%s", i+1, card.Text()))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// ===============================
+// Templates
+// ===============================
+
+// Template renders a template definition with the parameters of content
+// substituted into its header, body and buttons.
+func Template(t *models.Template, content *models.TemplateContent) (*Preview, error) {
+	if t == nil {
+		return nil, errors.NewValidationError("template", "template definition is required")
+	}
+
+	var components []models.TemplateComponent
+	if content != nil {
+		components = content.Components
+	}
+
+	p := &Preview{Type: models.MessageTypeTemplate}
+	if err := renderTemplate(p, t.Components, components); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// renderTemplate fills p from component definitions and the parameters sent
+// for them. It is used for the template itself and for each carousel card.
+func renderTemplate(p *Preview, defs []models.TemplateComponentDef, components []models.TemplateComponent) error {
+	var header, body []models.TemplateParameter
+	var offer *models.LimitedTimeOfferParam
+	var cards []models.TemplateCard
+	buttonParams := make(map[int]models.TemplateParameter)
+
+	for _, comp := range components {
+		switch comp.Type {
+		case models.TemplateComponentHeader:
+			header = comp.Parameters
+		case models.TemplateComponentBody:
+			body = comp.Parameters
+		case models.TemplateComponentLimitedTimeOffer:
+			if len(comp.Parameters) > 0 {
+				offer = comp.Parameters[0].LimitedTimeOffer
+			}
+		case models.TemplateComponentCarousel:
+			cards = comp.Cards
+		case models.TemplateComponentButton:
+			index, err := strconv.Atoi(comp.Index)
+			if err != nil {
+				return errors.NewValidationError("button.index", fmt.Sprintf("invalid button index %q", comp.Index))
+			}
+			if len(comp.Parameters) > 0 {
+				buttonParams[index] = comp.Parameters[0]
+			}
+		}
+	}
+
+	for _, comp := range defs {
+		switch strings.ToUpper(comp.Type) {
+		case models.TemplateDefHeader:
+			if strings.ToUpper(comp.Format) == models.TemplateFormatText {
+				p.Header = substitute(comp.Text, header)
+			} else if len(header) > 0 {
+				p.Header = paramValue(&header[0])
+			} else {
+				p.Header = "[" + strings.ToLower(comp.Format) + "]"
+			}
+		case models.TemplateDefBody:
+			p.Body = substitute(comp.Text, body)
+		case models.TemplateDefFooter:
+			p.Footer = comp.Text
+		case models.TemplateDefLimitedTimeOffer:
+			if comp.LimitedTimeOffer != nil {
+				p.Offer = comp.LimitedTimeOffer.Text
+			}
+			if offer != nil {
+				p.Offer += " (ends " + time.UnixMilli(offer.ExpirationTimeMs).UTC().Format(time.RFC1123) + ")"
+			}
+		case models.TemplateDefButtons:
+			for i, btn := range comp.Buttons {
+				rendered := Button{Type: strings.ToLower(btn.Type), Text: btn.Text}
+				switch strings.ToUpper(btn.Type) {
+				case models.TemplateButtonURL:
+					var params []models.TemplateParameter
+					if param, ok := buttonParams[i]; ok {
+						params = append(params, param)
+					}
+					rendered.Value = substitute(btn.URL, params)
+				case models.TemplateButtonPhoneNumber:
+					rendered.Value = btn.PhoneNumber
+				case models.TemplateButtonQuickReply:
+					if param, ok := buttonParams[i]; ok {
+						rendered.Value = param.Payload
+					}
+				case models.TemplateButtonOTP:
+					if param, ok := buttonParams[i]; ok {
+						rendered.Value = paramValue(&param)
+					}
+				case models.TemplateButtonCopyCode:
+					if rendered.Text == "" {
+						rendered.Text = "Copy offer code"
+					}
+					if param, ok := buttonParams[i]; ok {
+						rendered.Value = param.CouponCode
+					}
+				case models.TemplateButtonFlow:
+					rendered.Value = "flow " + firstNonEmpty(btn.FlowID, btn.FlowName)
+					if param, ok := buttonParams[i]; ok && param.Action != nil && param.Action.FlowToken != "" {
+						rendered.Value += " (token " + param.Action.FlowToken + ")"
+					}
+				}
+				p.Buttons = append(p.Buttons, rendered)
+			}
+		case models.TemplateDefCarousel:
+			for i, card := range comp.Cards {
+				var cardComponents []models.TemplateComponent
+				for _, c := range cards {
+					if c.CardIndex == i {
+						cardComponents = c.Components
+					}
+				}
+				cardPreview := &Preview{Type: models.MessageTypeTemplate}
+				if err := renderTemplate(cardPreview, card.Components, cardComponents); err != nil {
+					return err
+				}
+				p.Cards = append(p.Cards, cardPreview)
+			}
+		}
+	}
+
+	return nil
+}
+
+// substitute replaces {{n}} or {{name}} placeholders with parameter values.
+// Placeholders without a matching parameter are left as they are.
+func substitute(text string, params []models.TemplateParameter) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		key := strings.TrimSpace(m[2 : len(m)-2])
+		if n, err := strconv.Atoi(key); err == nil {
+			if n >= 1 && n <= len(params) {
+				return paramValue(&params[n-1])
+			}
+			return m
+		}
+		for i := range params {
+			if params[i].ParameterName == key {
+				return paramValue(&params[i])
+			}
+		}
+		return m
+	})
+}
+
+// paramValue returns the display value of a template parameter.
+func paramValue(p *models.TemplateParameter) string {
+	switch p.Type {
+	case models.TemplateParamText:
+		return p.Text
+	case models.TemplateParamCurrency:
+		if p.Currency != nil {
+			return p.Currency.FallbackValue
+		}
+	case models.TemplateParamDateTime:
+		if p.DateTime != nil {
+			return p.DateTime.FallbackValue
+		}
+	case models.TemplateParamPayload:
+		return p.Payload
+	case models.TemplateParamImage:
+		return mediaMarker("image", p.Image)
+	case models.TemplateParamVideo:
+		return mediaMarker("video", p.Video)
+	case models.TemplateParamDocument:
+		if p.Document != nil {
+			return documentMarker(p.Document)
+		}
+	case models.TemplateParamLocation:
+		if p.Location != nil {
+			return locationText(p.Location)
+		}
+	}
+	return "[" + string(p.Type) + "]"
+}
+
+// ===============================
+// Messages
+// ===============================
+
+// Message renders any message request. Template messages are rendered by
+// name and parameters only; use Template to render them against their
+// definition.
+func Message(req *models.MessageRequest) (*Preview, error) {
+	if req == nil {
+		return nil, errors.NewValidationError("request", "message request is required")
+	}
+
+	p := &Preview{Type: req.Type, To: req.To}
+
+	switch req.Type {
+	case models.MessageTypeText:
+		if req.Text == nil {
+			return nil, errors.NewValidationError("text", "text content is required")
+		}
+		p.Body = req.Text.Body
+
+	case models.MessageTypeImage, models.MessageTypeVideo, models.MessageTypeAudio, models.MessageTypeSticker:
+		media := mediaFor(req)
+		if media == nil {
+			return nil, errors.NewValidationError(string(req.Type), "media content is required")
+		}
+		p.Header = mediaMarker(string(req.Type), media)
+		p.Body = media.Caption
+
+	case models.MessageTypeDocument:
+		if req.Document == nil {
+			return nil, errors.NewValidationError("document", "document content is required")
+		}
+		p.Header = documentMarker(req.Document)
+		p.Body = req.Document.Caption
+
+	case models.MessageTypeLocation:
+		if req.Location == nil {
+			return nil, errors.NewValidationError("location", "location content is required")
+		}
+		p.Body = locationText(req.Location)
+
+	case models.MessageTypeContacts:
+		lines := make([]string, len(req.Contacts))
+		for i, contact := range req.Contacts {
+			lines[i] = contactText(&contact)
+		}
+		p.Body = strings.Join(lines, "\n")
+
+	case models.MessageTypeReaction:
+		if req.Reaction == nil {
+			return nil, errors.NewValidationError("reaction", "reaction content is required")
+		}
+		if req.Reaction.Emoji == "" {
+			p.Body = fmt.Sprintf("[remove reaction from %s]", req.Reaction.MessageID)
+		} else {
+			p.Body = fmt.Sprintf("[reaction %s to %s]", req.Reaction.Emoji, req.Reaction.MessageID)
+		}
+
+	case models.MessageTypeInteractive:
+		if req.Interactive == nil {
+			return nil, errors.NewValidationError("interactive", "interactive content is required")
+		}
+		renderInteractive(p, req.Interactive)
+
+	case models.MessageTypeTemplate:
+		if req.Template == nil {
+			return nil, errors.NewValidationError("template", "template content is required")
+		}
+		p.Header = fmt.Sprintf("[template %s (%s)]", req.Template.Name, req.Template.Language.Code)
+		var lines []string
+		for _, comp := range req.Template.Components {
+			values := make([]string, len(comp.Parameters))
+			for i := range comp.Parameters {
+				values[i] = paramValue(&comp.Parameters[i])
+			}
+			name := string(comp.Type)
+			if comp.Type == models.TemplateComponentButton {
+				name += " " + comp.Index
+			}
+			lines = append(lines, name+": "+strings.Join(values, ", "))
+		}
+		p.Body = strings.Join(lines, "\n")
+
+	default:
+		return nil, errors.NewValidationError("type", fmt.Sprintf("unsupported message type %q", req.Type))
+	}
+
+	return p, nil
+}
+
+// renderInteractive fills p from interactive message content.
+func renderInteractive(p *Preview, in *models.InteractiveContent) {
+	if in.Header != nil {
+		switch in.Header.Type {
+		case "text":
+			p.Header = in.Header.Text
+		case "image":
+			p.Header = mediaMarker("image", in.Header.Image)
+		case "video":
+			p.Header = mediaMarker("video", in.Header.Video)
+		case "document":
+			p.Header = mediaMarker("document", in.Header.Document)
+		}
+	}
+	p.Body = in.Body.Text
+	if in.Footer != nil {
+		p.Footer = in.Footer.Text
+	}
+
+	action := &in.Action
+	for _, btn := range action.Buttons {
+		p.Buttons = append(p.Buttons, Button{Type: btn.Type, Text: btn.Reply.Title, Value: btn.Reply.ID})
+	}
+
+	switch in.Type {
+	case models.InteractiveTypeList:
+		p.ListButton = action.Button
+		for _, section := range action.Sections {
+			rendered := Section{Title: section.Title}
+			for _, row := range section.Rows {
+				rendered.Rows = append(rendered.Rows, Row{ID: row.ID, Title: row.Title, Description: row.Description})
+			}
+			p.Sections = append(p.Sections, rendered)
+		}
+	case models.InteractiveTypeProduct:
+		p.Buttons = append(p.Buttons, Button{Type: "product", Text: "View", Value: action.ProductRetailerID})
+	case models.InteractiveTypeProductList:
+		p.ListButton = "View items"
+		for _, section := range action.Sections {
+			rendered := Section{Title: section.Title}
+			for _, item := range section.ProductItems {
+				rendered.Rows = append(rendered.Rows, Row{ID: item.ProductRetailerID, Title: item.ProductRetailerID})
+			}
+			p.Sections = append(p.Sections, rendered)
+		}
+	case models.InteractiveTypeOrderDetails:
+		if params := action.Parameters; params != nil && params.Order != nil {
+			section := Section{Title: "Order " + params.ReferenceID}
+			for _, item := range params.Order.Items {
+				price := item.Amount
+				if item.SaleAmount != nil {
+					price = *item.SaleAmount
+				}
+				section.Rows = append(section.Rows, Row{
+					ID:          item.RetailerID,
+					Title:       fmt.Sprintf("%d x %s", item.Quantity, item.Name),
+					Description: formatAmount(price.Value, price.Offset, params.Currency),
+				})
+			}
+			p.Sections = append(p.Sections, section)
+			if params.TotalAmount != nil {
+				p.Buttons = append(p.Buttons, Button{Type: "order_details", Text: "Review and pay", Value: "total " + formatAmount(params.TotalAmount.Value, params.TotalAmount.Offset, params.Currency)})
+			}
+		}
+	case models.InteractiveTypeOrderStatus:
+		if params := action.Parameters; params != nil && params.Order != nil {
+			value := string(params.Order.Status)
+			if params.Order.Description != "" {
+				value += ": " + params.Order.Description
+			}
+			p.Buttons = append(p.Buttons, Button{Type: "order_status", Text: "Order " + params.ReferenceID, Value: value})
+		}
+	case models.InteractiveTypeLocationRequest:
+		p.Buttons = append(p.Buttons, Button{Type: "send_location", Text: "Send location"})
+	case models.InteractiveTypeAddress:
+		button := Button{Type: "address", Text: "Provide address"}
+		if action.Parameters != nil {
+			button.Value = action.Parameters.Country
+		}
+		p.Buttons = append(p.Buttons, button)
+	case models.InteractiveTypeVoiceCall:
+		button := Button{Type: "voice_call", Text: "Call now"}
+		if action.Parameters != nil && action.Parameters.DisplayText != "" {
+			button.Text = action.Parameters.DisplayText
+		}
+		p.Buttons = append(p.Buttons, button)
+	case models.InteractiveTypeCallPermission:
+		p.Buttons = append(p.Buttons, Button{Type: "call_permission_request", Text: "Allow calls"})
+	case models.InteractiveTypeCatalog:
+		button := Button{Type: "catalog", Text: "View catalog"}
+		if action.Parameters != nil {
+			button.Value = action.Parameters.ThumbnailProductRetailerID
+		}
+		p.Buttons = append(p.Buttons, button)
+	case models.InteractiveTypeCTA:
+		if action.Parameters != nil {
+			p.Buttons = append(p.Buttons, Button{Type: "url", Text: action.Parameters.DisplayText, Value: action.Parameters.URL})
+		}
+	case models.InteractiveTypeFlow:
+		if params := action.Parameters; params != nil {
+			value := "flow " + firstNonEmpty(params.FlowID, params.FlowName)
+			if params.FlowActionPayload != nil && params.FlowActionPayload.Screen != "" {
+				value += " at " + params.FlowActionPayload.Screen
+			}
+			if params.Mode == models.FlowModeDraft {
+				value += " (draft)"
+			}
+			p.Buttons = append(p.Buttons, Button{Type: "flow", Text: params.FlowCTA, Value: value})
+		}
+	}
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+func mediaFor(req *models.MessageRequest) *models.MediaContent {
+	switch req.Type {
+	case models.MessageTypeImage:
+		return req.Image
+	case models.MessageTypeVideo:
+		return req.Video
+	case models.MessageTypeAudio:
+		return req.Audio
+	case models.MessageTypeSticker:
+		return req.Sticker
+	}
+	return nil
+}
+
+func mediaMarker(kind string, media *models.MediaContent) string {
+	if media == nil {
+		return "[" + kind + "]"
+	}
+	return fmt.Sprintf("[%s: %s]", kind, firstNonEmpty(media.Link, media.ID, media.Path))
+}
+
+func documentMarker(doc *models.DocumentContent) string {
+	source := firstNonEmpty(doc.Link, doc.ID, doc.Path)
+	if doc.Filename != "" {
+		return fmt.Sprintf("[document: %s (%s)]", doc.Filename, source)
+	}
+	return fmt.Sprintf("[document: %s]", source)
+}
+
+func locationText(loc *models.LocationContent) string {
+	var parts []string
+	if loc.Name != "" {
+		parts = append(parts, loc.Name)
+	}
+	if loc.Address != "" {
+		parts = append(parts, loc.Address)
+	}
+	parts = append(parts, fmt.Sprintf("(%g, %g)", loc.Latitude, loc.Longitude))
+	return "[location] " + strings.Join(parts, ", ")
+}
+
+func contactText(contact *models.ContactContent) string {
+	line := "[contact] " + contact.Name.FormattedName
+	for _, phone := range contact.Phones {
+		line += ", " + phone.Phone
+	}
+	for _, email := range contact.Emails {
+		line += ", " + email.Email
+	}
+	return line
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// formatAmount formats value / offset with the currency code.
+func formatAmount(value, offset int64, currency string) string {
+	if offset <= 0 {
+		offset = 1
+	}
+	decimals := 0
+	for o := offset; o > 1; o /= 10 {
+		decimals++
+	}
+	return fmt.Sprintf("%.*f %s", decimals, float64(value)/float64(offset), currency)
+}
@@ -0,0 +1,229 @@
+package preview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *models.Template
+		content  *models.TemplateContent
+		want     string
+	}{
+		{"positional", &models.Template{
+			Components: []models.TemplateComponentDef{
+				{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Order {{1}}"},
+				{Type: models.TemplateDefBody, Text: "Hi {{1}}, your total is {{2}}, due {{3}}."},
+				{Type: models.TemplateDefFooter, Text: "Reply STOP to opt out"},
+				{Type: models.TemplateDefButtons, Buttons: []models.TemplateButtonDef{
+					{Type: models.TemplateButtonURL, Text: "Track", URL: "https://example.com/track/{{1}}"},
+					{Type: models.TemplateButtonPhoneNumber, Text: "Call us", PhoneNumber: "+15551234567"},
+					{Type: models.TemplateButtonQuickReply, Text: "Stop"},
+				}},
+			},
+		}, &models.TemplateContent{Components: []models.TemplateComponent{
+			{Type: models.TemplateComponentHeader, Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "A123"}}},
+			{Type: models.TemplateComponentBody, Parameters: []models.TemplateParameter{
+				{Type: models.TemplateParamText, Text: "Ann"},
+				{Type: models.TemplateParamCurrency, Currency: &models.CurrencyParam{FallbackValue: "$10.00", Code: "USD", Amount1000: 10000}},
+				{Type: models.TemplateParamDateTime, DateTime: &models.DateTimeParam{FallbackValue: "Monday"}},
+			}},
+			{Type: models.TemplateComponentButton, SubType: "url", Index: "0", Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "A123"}}},
+			{Type: models.TemplateComponentButton, SubType: "quick_reply", Index: "2", Parameters: []models.TemplateParameter{{Type: models.TemplateParamPayload, Payload: "stop"}}},
+		}}, `Order A123
+
+Hi Ann, your total is $10.00, due Monday.
+
+Reply STOP to opt out
+
+[Track] https://example.com/track/A123
+[Call us] +15551234567
+[Stop]`},
+		{"named without parameters", &models.Template{
+			ParameterFormat: "NAMED",
+			Components: []models.TemplateComponentDef{
+				{Type: models.TemplateDefHeader, Format: "IMAGE"},
+				{Type: models.TemplateDefBody, Text: "Hi {{first_name}}, order {{order_id}} shipped."},
+			},
+		}, &models.TemplateContent{Components: []models.TemplateComponent{
+			{Type: models.TemplateComponentBody, Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "A123", ParameterName: "order_id"}}},
+		}}, `[image]
+
+Hi {{first_name}}, order A123 shipped.`},
+		{"offer, coupon and flow", &models.Template{
+			Components: []models.TemplateComponentDef{
+				{Type: models.TemplateDefHeader, Format: "IMAGE"},
+				{Type: models.TemplateDefLimitedTimeOffer, LimitedTimeOffer: &models.LimitedTimeOfferDef{Text: "Ends soon", HasExpiration: true}},
+				{Type: models.TemplateDefBody, Text: "Save 20% today"},
+				{Type: models.TemplateDefButtons, Buttons: []models.TemplateButtonDef{
+					{Type: models.TemplateButtonCopyCode},
+					{Type: models.TemplateButtonFlow, Text: "Shop", FlowID: "123"},
+				}},
+			},
+		}, &models.TemplateContent{Components: []models.TemplateComponent{
+			{Type: models.TemplateComponentHeader, Parameters: []models.TemplateParameter{{Type: models.TemplateParamImage, Image: &models.MediaContent{Link: "https://example.com/a.jpg"}}}},
+			{Type: models.TemplateComponentLimitedTimeOffer, Parameters: []models.TemplateParameter{{Type: models.TemplateParamLimitedTimeOffer, LimitedTimeOffer: &models.LimitedTimeOfferParam{ExpirationTimeMs: time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC).UnixMilli()}}}},
+			{Type: models.TemplateComponentButton, SubType: "copy_code", Index: "0", Parameters: []models.TemplateParameter{{Type: models.TemplateParamCouponCode, CouponCode: "SAVE20"}}},
+			{Type: models.TemplateComponentButton, SubType: "flow", Index: "1", Parameters: []models.TemplateParameter{{Type: models.TemplateParamAction, Action: &models.TemplateActionParam{FlowToken: "token"}}}},
+		}}, `[image: https://example.com/a.jpg]
+
+[offer] Ends soon (ends Sun, 30 Jun 2024 12:00:00 UTC)
+
+Save 20% today
+
+[Copy offer code] SAVE20
+[Shop] flow 123 (token token)`},
+		{"carousel", &models.Template{
+			Components: []models.TemplateComponentDef{
+				{Type: models.TemplateDefBody, Text: "Our picks"},
+				{Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+					{Components: []models.TemplateComponentDef{
+						{Type: models.TemplateDefHeader, Format: "IMAGE"},
+						{Type: models.TemplateDefBody, Text: "Item {{1}}"},
+						{Type: models.TemplateDefButtons, Buttons: []models.TemplateButtonDef{{Type: models.TemplateButtonURL, Text: "Buy", URL: "https://example.com/{{1}}"}}},
+					}},
+					{Components: []models.TemplateComponentDef{
+						{Type: models.TemplateDefHeader, Format: "IMAGE"},
+						{Type: models.TemplateDefBody, Text: "Item {{1}}"},
+						{Type: models.TemplateDefButtons, Buttons: []models.TemplateButtonDef{{Type: models.TemplateButtonURL, Text: "Buy", URL: "https://example.com/{{1}}"}}},
+					}},
+				}},
+			},
+		}, &models.TemplateContent{Components: []models.TemplateComponent{
+			{Type: models.TemplateComponentCarousel, Cards: []models.TemplateCard{
+				{CardIndex: 1, Components: []models.TemplateComponent{
+					{Type: models.TemplateComponentHeader, Parameters: []models.TemplateParameter{{Type: models.TemplateParamImage, Image: &models.MediaContent{ID: "42"}}}},
+					{Type: models.TemplateComponentBody, Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "B"}}},
+					{Type: models.TemplateComponentButton, SubType: "url", Index: "0", Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "b"}}},
+				}},
+			}},
+		}}, `Our picks
+
+--- card 1 ---
+[image]
+
+Item {{1}}
+
+[Buy] https://example.com/{{1}}
+
+--- card 2 ---
+[image: 42]
+
+Item B
+
+[Buy] https://example.com/b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Template(tt.template, tt.content)
+			if err != nil {
+				t.Fatalf("Template: %v", err)
+			}
+			if got := p.Text(); got != tt.want {
+				t.Fatalf("Template preview =\n%s\n\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	interactive := func(content *models.InteractiveContent) *models.MessageRequest {
+		return &models.MessageRequest{Type: models.MessageTypeInteractive, To: "15551234567", Interactive: content}
+	}
+
+	tests := []struct {
+		name string
+		req  *models.MessageRequest
+		want string
+	}{
+		{"text", &models.MessageRequest{Type: models.MessageTypeText, Text: &models.TextContent{Body: "Hello"}}, `Hello`},
+		{"document", &models.MessageRequest{Type: models.MessageTypeDocument, Document: &models.DocumentContent{ID: "42", Filename: "invoice.pdf", Caption: "Your invoice"}},
+			`[document: invoice.pdf (42)]
+
+Your invoice`},
+		{"location", &models.MessageRequest{Type: models.MessageTypeLocation, Location: &models.LocationContent{
+			Latitude: 52.52, Longitude: 13.405, Name: "Office", Address: "Main St 1",
+		}}, `[location] Office, Main St 1, (52.52, 13.405)`},
+		{"contacts", &models.MessageRequest{Type: models.MessageTypeContacts, Contacts: []models.ContactContent{
+			{Name: models.ContactName{FormattedName: "Ann Lee"}, Phones: []models.ContactPhone{{Phone: "+15551234567"}}, Emails: []models.ContactEmail{{Email: "ann@example.com"}}},
+			{Name: models.ContactName{FormattedName: "Bo Chen"}},
+		}}, `[contact] Ann Lee, +15551234567, ann@example.com
+[contact] Bo Chen`},
+		{"reply buttons", interactive(&models.InteractiveContent{
+			Type:   models.InteractiveTypeButton,
+			Header: &models.InteractiveHeader{Type: "text", Text: "Delivery"},
+			Body:   models.InteractiveBody{Text: "Is now a good time?"},
+			Footer: &models.InteractiveFooter{Text: "Tap to answer"},
+			Action: models.InteractiveAction{Buttons: []models.InteractiveButton{
+				{Type: "reply", Reply: models.InteractiveReply{ID: "yes", Title: "Yes"}},
+				{Type: "reply", Reply: models.InteractiveReply{ID: "no", Title: "No"}},
+			}},
+		}), `Delivery
+
+Is now a good time?
+
+Tap to answer
+
+[Yes]
+[No]`},
+		{"list", interactive(&models.InteractiveContent{
+			Type: models.InteractiveTypeList,
+			Body: models.InteractiveBody{Text: "Pick a slot"},
+			Action: models.InteractiveAction{Button: "Slots", Sections: []models.InteractiveSection{
+				{Title: "Morning", Rows: []models.InteractiveRow{{ID: "9", Title: "9:00", Description: "Fastest"}, {ID: "10", Title: "10:00"}}},
+				{Title: "Evening", Rows: []models.InteractiveRow{{ID: "18", Title: "18:00"}}},
+			}},
+		}), `Pick a slot
+
+[Slots]
+Morning
+  - 9:00: Fastest
+  - 10:00
+Evening
+  - 18:00`},
+		{"CTA URL", interactive(&models.InteractiveContent{
+			Type:   models.InteractiveTypeCTA,
+			Header: &models.InteractiveHeader{Type: "image", Image: &models.MediaContent{Link: "https://example.com/a.jpg"}},
+			Body:   models.InteractiveBody{Text: "See our menu"},
+			Action: models.InteractiveAction{Name: "cta_url", Parameters: &models.ActionParameters{DisplayText: "Menu", URL: "https://example.com/menu"}},
+		}), `[image: https://example.com/a.jpg]
+
+See our menu
+
+[Menu] https://example.com/menu`},
+		{"location request", interactive(&models.InteractiveContent{
+			Type: models.InteractiveTypeLocationRequest,
+			Body: models.InteractiveBody{Text: "Where should we deliver?"},
+		}), `Where should we deliver?
+
+[Send location]`},
+		{"template", &models.MessageRequest{Type: models.MessageTypeTemplate, Template: &models.TemplateContent{
+			Name:     "order_update",
+			Language: models.TemplateLanguage{Code: "en_US"},
+			Components: []models.TemplateComponent{
+				{Type: models.TemplateComponentBody, Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "Ann"}, {Type: models.TemplateParamText, Text: "A123"}}},
+				{Type: models.TemplateComponentButton, SubType: "url", Index: "0", Parameters: []models.TemplateParameter{{Type: models.TemplateParamText, Text: "A123"}}},
+			},
+		}}, `[template order_update (en_US)]
+
+body: Ann, A123
+button 0: A123`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Message(tt.req)
+			if err != nil {
+				t.Fatalf("Message: %v", err)
+			}
+			if got := p.Text(); got != tt.want {
+				t.Fatalf("Message preview =\n%s\n\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
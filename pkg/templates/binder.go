@@ -0,0 +1,578 @@
+// Package templates provides type-safe binding of arguments to fetched template definitions.
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+var namedPlaceholderPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ===============================
+// Parameter Helpers
+// ===============================
+
+// Text returns a text parameter.
+func Text(text string) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamText, Text: text}
+}
+
+// Currency returns a currency parameter. amount1000 is the amount multiplied by 1000.
+func Currency(fallback, code string, amount1000 int64) models.TemplateParameter {
+	return models.TemplateParameter{
+		Type: models.TemplateParamCurrency,
+		Currency: &models.CurrencyParam{
+			FallbackValue: fallback,
+			Code:          code,
+			Amount1000:    amount1000,
+		},
+	}
+}
+
+// DateTime returns a date/time parameter.
+func DateTime(fallback string) models.TemplateParameter {
+	return models.TemplateParameter{
+		Type:     models.TemplateParamDateTime,
+		DateTime: &models.DateTimeParam{FallbackValue: fallback},
+	}
+}
+
+// Image returns an image header parameter from a media ID or link.
+func Image(media models.MediaContent) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamImage, Image: &media}
+}
+
+// Video returns a video header parameter from a media ID or link.
+func Video(media models.MediaContent) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamVideo, Video: &media}
+}
+
+// Document returns a document header parameter from a media ID or link.
+func Document(doc models.DocumentContent) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamDocument, Document: &doc}
+}
+
+// Location returns a location header parameter.
+func Location(location models.LocationContent) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamLocation, Location: &location}
+}
+
+// CouponCode returns a copy code button parameter.
+func CouponCode(code string) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamCouponCode, CouponCode: code}
+}
+
+// FlowAction returns a flow button parameter with the flow token and
+// optional initial screen data.
+func FlowAction(flowToken string, data map[string]interface{}) models.TemplateParameter {
+	return models.TemplateParameter{
+		Type:   models.TemplateParamAction,
+		Action: &models.TemplateActionParam{FlowToken: flowToken, FlowActionData: data},
+	}
+}
+
+// Payload returns a quick reply button payload parameter.
+func Payload(payload string) models.TemplateParameter {
+	return models.TemplateParameter{Type: models.TemplateParamPayload, Payload: payload}
+}
+
+// ===============================
+// Binder
+// ===============================
+
+// Args contains the values to bind to a template's placeholders.
+type Args struct {
+	// Header is the header parameter: text for a TEXT header with a
+	// placeholder, or the media/location for IMAGE, VIDEO, DOCUMENT and
+	// LOCATION headers.
+	Header *models.TemplateParameter
+
+	// Body contains positional body parameters, Body[0] binds {{1}}.
+	Body []models.TemplateParameter
+
+	// Named contains header and body parameters for templates using named
+	// placeholders, keyed by placeholder name.
+	Named map[string]models.TemplateParameter
+
+	// Buttons contains button parameters keyed by button index: the URL
+	// suffix (text) for dynamic URL buttons, the code (text) for OTP
+	// buttons, the coupon code for copy code buttons, the flow action for
+	// flow buttons, or the payload for quick replies.
+	Buttons map[int]models.TemplateParameter
+
+	// OfferExpiration is the expiration of a limited-time offer that has one.
+	OfferExpiration time.Time
+
+	// Cards contains the arguments of each carousel card, in card order.
+	Cards []Args
+}
+
+// buttonSpec describes a button that accepts a parameter.
+type buttonSpec struct {
+	index    int
+	subType  string
+	required bool
+	otp      bool
+	coupon   bool
+}
+
+// Binder validates arguments against a template definition and builds the
+// matching TemplateContent.
+type Binder struct {
+	template     *models.Template
+	named        bool
+	headerFormat string
+	headerParams []string
+	bodyParams   []string
+	buttons      map[int]buttonSpec
+	offer        *models.LimitedTimeOfferDef
+	cards        []*Binder
+}
+
+// NewBinder parses the header, body and button placeholders of a template
+// as returned by GetTemplate.
+func NewBinder(t *models.Template) (*Binder, error) {
+	if t == nil {
+		return nil, errors.NewValidationError("template", "template is required")
+	}
+	if t.Name == "" {
+		return nil, errors.NewValidationError("template.name", "template name is required")
+	}
+	if t.Language == "" {
+		return nil, errors.NewValidationError("template.language", "template language is required")
+	}
+
+	b := &Binder{
+		template: t,
+		named:    strings.EqualFold(t.ParameterFormat, models.TemplateParameterFormatNamed),
+		buttons:  make(map[int]buttonSpec),
+	}
+
+	if err := b.parseComponents(t.Components); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// parseComponents records the placeholders of the template or card components.
+func (b *Binder) parseComponents(components []models.TemplateComponentDef) error {
+	for _, comp := range components {
+		switch strings.ToUpper(comp.Type) {
+		case models.TemplateDefHeader:
+			b.headerFormat = strings.ToUpper(comp.Format)
+			if b.headerFormat == models.TemplateFormatText {
+				params, err := b.parse("header", comp.Text)
+				if err != nil {
+					return err
+				}
+				b.headerParams = params
+			}
+		case models.TemplateDefBody:
+			params, err := b.parse("body", comp.Text)
+			if err != nil {
+				return err
+			}
+			b.bodyParams = params
+		case models.TemplateDefButtons:
+			for i, btn := range comp.Buttons {
+				switch strings.ToUpper(btn.Type) {
+				case models.TemplateButtonURL:
+					if len(Placeholders(btn.URL)) > 0 {
+						b.buttons[i] = buttonSpec{index: i, subType: models.TemplateButtonSubTypeURL, required: true}
+					}
+				case models.TemplateButtonOTP:
+					b.buttons[i] = buttonSpec{index: i, subType: models.TemplateButtonSubTypeURL, required: true, otp: true}
+				case models.TemplateButtonCopyCode:
+					b.buttons[i] = buttonSpec{index: i, subType: models.TemplateButtonSubTypeCopyCode, required: true, coupon: true}
+				case models.TemplateButtonQuickReply:
+					b.buttons[i] = buttonSpec{index: i, subType: models.TemplateButtonSubTypeQuickReply}
+				case models.TemplateButtonFlow:
+					b.buttons[i] = buttonSpec{index: i, subType: models.TemplateButtonSubTypeFlow}
+				}
+			}
+		case models.TemplateDefLimitedTimeOffer:
+			b.offer = comp.LimitedTimeOffer
+		case models.TemplateDefCarousel:
+			for i, card := range comp.Cards {
+				cb := &Binder{
+					template: b.template,
+					named:    b.named,
+					buttons:  make(map[int]buttonSpec),
+				}
+				if err := cb.parseComponents(card.Components); err != nil {
+					return fmt.Errorf("card %d: %w", i, err)
+				}
+				b.cards = append(b.cards, cb)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parse returns the placeholder keys of text: "1".."n" for positional
+// templates, or the placeholder names in order of appearance for named ones.
+func (b *Binder) parse(field, text string) ([]string, error) {
+	var keys []string
+	seen := make(map[string]bool)
+
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		key := strings.TrimSpace(m[1])
+		if b.named {
+			if !namedPlaceholderPattern.MatchString(key) {
+				return nil, errors.NewValidationError(field, fmt.Sprintf("invalid named placeholder %s", m[0]))
+			}
+		} else if n, err := strconv.Atoi(key); err != nil || n < 1 {
+			return nil, errors.NewValidationError(field, fmt.Sprintf("invalid placeholder %s", m[0]))
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	if !b.named {
+		sort.Slice(keys, func(i, j int) bool {
+			a, _ := strconv.Atoi(keys[i])
+			c, _ := strconv.Atoi(keys[j])
+			return a < c
+		})
+	}
+
+	return keys, nil
+}
+
+// HeaderFormat returns the header format (TEXT, IMAGE, ...) or "" if the
+// template has no header.
+func (b *Binder) HeaderFormat() string {
+	return b.headerFormat
+}
+
+// HeaderParams returns the header placeholder keys.
+func (b *Binder) HeaderParams() []string {
+	return b.headerParams
+}
+
+// BodyParams returns the body placeholder keys: "1".."n" for positional
+// templates, or the placeholder names for named ones.
+func (b *Binder) BodyParams() []string {
+	return b.bodyParams
+}
+
+// Bind validates args against the template and returns the TemplateContent
+// to send. All mismatches are reported together as errors.ValidationErrors.
+func (b *Binder) Bind(args Args) (*models.TemplateContent, error) {
+	var errs errors.ValidationErrors
+
+	components := b.bindComponents(&errs, &args)
+
+	if err := errs.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &models.TemplateContent{
+		Name:       b.template.Name,
+		Language:   models.TemplateLanguage{Code: b.template.Language},
+		Components: components,
+	}, nil
+}
+
+// bindComponents binds the arguments of the template or of a single card.
+func (b *Binder) bindComponents(errs *errors.ValidationErrors, args *Args) []models.TemplateComponent {
+	var components []models.TemplateComponent
+
+	if header := b.bindHeader(errs, args); header != nil {
+		components = append(components, *header)
+	}
+	if body := b.bindBody(errs, args); body != nil {
+		components = append(components, *body)
+	}
+	if offer := b.bindOffer(errs, args); offer != nil {
+		components = append(components, *offer)
+	}
+	components = append(components, b.bindButtons(errs, args)...)
+	if carousel := b.bindCards(errs, args); carousel != nil {
+		components = append(components, *carousel)
+	}
+
+	if b.named {
+		for name := range args.Named {
+			if !contains(b.headerParams, name) && !contains(b.bodyParams, name) {
+				errs.Add("named."+name, "template has no placeholder with this name")
+			}
+		}
+	} else if len(args.Named) > 0 {
+		errs.Add("named", "template uses positional placeholders")
+	}
+
+	return components
+}
+
+func (b *Binder) bindOffer(errs *errors.ValidationErrors, args *Args) *models.TemplateComponent {
+	if b.offer == nil || !b.offer.HasExpiration {
+		if !args.OfferExpiration.IsZero() {
+			errs.Add("offer_expiration", "template has no expiring limited-time offer")
+		}
+		return nil
+	}
+	if args.OfferExpiration.IsZero() {
+		errs.Add("offer_expiration", "missing limited-time offer expiration")
+		return nil
+	}
+
+	return &models.TemplateComponent{
+		Type: models.TemplateComponentLimitedTimeOffer,
+		Parameters: []models.TemplateParameter{
+			{
+				Type:             models.TemplateParamLimitedTimeOffer,
+				LimitedTimeOffer: &models.LimitedTimeOfferParam{ExpirationTimeMs: args.OfferExpiration.UnixMilli()},
+			},
+		},
+	}
+}
+
+func (b *Binder) bindCards(errs *errors.ValidationErrors, args *Args) *models.TemplateComponent {
+	if len(b.cards) == 0 {
+		if len(args.Cards) > 0 {
+			errs.Add("cards", "template has no carousel")
+		}
+		return nil
+	}
+	if len(args.Cards) != len(b.cards) {
+		errs.Add("cards", fmt.Sprintf("template carousel has %d card(s), got %d", len(b.cards), len(args.Cards)))
+		return nil
+	}
+
+	cards := make([]models.TemplateCard, len(b.cards))
+	for i, cb := range b.cards {
+		var cardErrs errors.ValidationErrors
+		cards[i] = models.TemplateCard{
+			CardIndex:  i,
+			Components: cb.bindComponents(&cardErrs, &args.Cards[i]),
+		}
+		for _, e := range cardErrs {
+			errs.Add(fmt.Sprintf("cards[%d].%s", i, e.Field), e.Message)
+		}
+	}
+
+	return &models.TemplateComponent{
+		Type:  models.TemplateComponentCarousel,
+		Cards: cards,
+	}
+}
+
+func (b *Binder) bindHeader(errs *errors.ValidationErrors, args *Args) *models.TemplateComponent {
+	var param models.TemplateParameter
+
+	switch b.headerFormat {
+	case "":
+		if args.Header != nil {
+			errs.Add("header", "template has no header")
+		}
+		return nil
+
+	case models.TemplateFormatText:
+		if len(b.headerParams) == 0 {
+			if args.Header != nil {
+				errs.Add("header", "template header has no placeholder")
+			}
+			return nil
+		}
+		if b.named {
+			if args.Header != nil {
+				errs.Add("header", "template uses named placeholders; set the header value in Named")
+				return nil
+			}
+			p, ok := args.Named[b.headerParams[0]]
+			if !ok {
+				errs.Add("named."+b.headerParams[0], "missing header parameter")
+				return nil
+			}
+			param = p
+			param.ParameterName = b.headerParams[0]
+		} else {
+			if args.Header == nil {
+				errs.Add("header", "missing header text parameter {{1}}")
+				return nil
+			}
+			param = *args.Header
+		}
+		if param.Type != models.TemplateParamText || param.Text == "" {
+			errs.Add("header", "header parameter must be non-empty text")
+			return nil
+		}
+
+	default:
+		if args.Header == nil {
+			errs.Add("header", fmt.Sprintf("missing %s header parameter", b.headerFormat))
+			return nil
+		}
+		param = *args.Header
+		if !validHeaderMedia(b.headerFormat, &param) {
+			errs.Add("header", fmt.Sprintf("header parameter must be %s with an ID or link", strings.ToLower(b.headerFormat)))
+			return nil
+		}
+	}
+
+	return &models.TemplateComponent{
+		Type:       models.TemplateComponentHeader,
+		Parameters: []models.TemplateParameter{param},
+	}
+}
+
+func (b *Binder) bindBody(errs *errors.ValidationErrors, args *Args) *models.TemplateComponent {
+	if !b.named && len(args.Body) != len(b.bodyParams) {
+		errs.Add("body", fmt.Sprintf("template body expects %d parameter(s), got %d", len(b.bodyParams), len(args.Body)))
+		return nil
+	}
+	if b.named && len(args.Body) > 0 {
+		errs.Add("body", "template uses named placeholders; set body values in Named")
+		return nil
+	}
+	if len(b.bodyParams) == 0 {
+		return nil
+	}
+
+	params := make([]models.TemplateParameter, len(b.bodyParams))
+	for i, key := range b.bodyParams {
+		var param models.TemplateParameter
+		field := fmt.Sprintf("body.{{%s}}", key)
+		if b.named {
+			p, ok := args.Named[key]
+			if !ok {
+				errs.Add("named."+key, "missing body parameter")
+				continue
+			}
+			param = p
+			param.ParameterName = key
+			field = "named." + key
+		} else {
+			param = args.Body[i]
+		}
+
+		switch param.Type {
+		case models.TemplateParamText:
+			if param.Text == "" {
+				errs.Add(field, "text parameter cannot be empty")
+			}
+		case models.TemplateParamCurrency:
+			if param.Currency == nil || param.Currency.Code == "" || param.Currency.FallbackValue == "" {
+				errs.Add(field, "currency parameter requires code and fallback value")
+			}
+		case models.TemplateParamDateTime:
+			if param.DateTime == nil || param.DateTime.FallbackValue == "" {
+				errs.Add(field, "date_time parameter requires a fallback value")
+			}
+		default:
+			errs.Add(field, fmt.Sprintf("body parameters must be text, currency or date_time, got %q", param.Type))
+		}
+		params[i] = param
+	}
+
+	return &models.TemplateComponent{
+		Type:       models.TemplateComponentBody,
+		Parameters: params,
+	}
+}
+
+func (b *Binder) bindButtons(errs *errors.ValidationErrors, args *Args) []models.TemplateComponent {
+	var components []models.TemplateComponent
+
+	indexes := make([]int, 0, len(b.buttons))
+	for i := range b.buttons {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	for _, i := range indexes {
+		spec := b.buttons[i]
+		field := fmt.Sprintf("buttons[%d]", i)
+		param, ok := args.Buttons[i]
+		if !ok {
+			switch {
+			case spec.otp:
+				errs.Add(field, "missing one-time code parameter for OTP button")
+			case spec.coupon:
+				errs.Add(field, "missing coupon code parameter for copy code button")
+			case spec.required:
+				errs.Add(field, "missing URL suffix parameter for dynamic URL button")
+			}
+			continue
+		}
+
+		switch spec.subType {
+		case models.TemplateButtonSubTypeURL:
+			if param.Type != models.TemplateParamText || param.Text == "" {
+				errs.Add(field, "URL button parameter must be non-empty text")
+				continue
+			}
+		case models.TemplateButtonSubTypeCopyCode:
+			if param.Type != models.TemplateParamCouponCode || param.CouponCode == "" {
+				errs.Add(field, "copy code button parameter must be a non-empty coupon code")
+				continue
+			}
+			if utf8.RuneCountInString(param.CouponCode) > MaxCouponCodeLength {
+				errs.Add(field, fmt.Sprintf("coupon code must be at most %d characters", MaxCouponCodeLength))
+				continue
+			}
+		case models.TemplateButtonSubTypeQuickReply:
+			if param.Type != models.TemplateParamPayload || param.Payload == "" {
+				errs.Add(field, "quick reply button parameter must be a non-empty payload")
+				continue
+			}
+		case models.TemplateButtonSubTypeFlow:
+			if param.Type != models.TemplateParamAction || param.Action == nil {
+				errs.Add(field, "flow button parameter must be a flow action")
+				continue
+			}
+		}
+
+		components = append(components, models.TemplateComponent{
+			Type:       models.TemplateComponentButton,
+			SubType:    spec.subType,
+			Index:      strconv.Itoa(spec.index),
+			Parameters: []models.TemplateParameter{param},
+		})
+	}
+
+	for i := range args.Buttons {
+		if _, ok := b.buttons[i]; !ok {
+			errs.Add(fmt.Sprintf("buttons[%d]", i), "template has no button accepting a parameter at this index")
+		}
+	}
+
+	return components
+}
+
+// validHeaderMedia reports whether param matches the header format.
+func validHeaderMedia(format string, param *models.TemplateParameter) bool {
+	switch format {
+	case models.TemplateFormatImage:
+		return param.Type == models.TemplateParamImage && param.Image != nil && (param.Image.ID != "" || param.Image.Link != "")
+	case models.TemplateFormatVideo:
+		return param.Type == models.TemplateParamVideo && param.Video != nil && (param.Video.ID != "" || param.Video.Link != "")
+	case models.TemplateFormatDocument:
+		return param.Type == models.TemplateParamDocument && param.Document != nil && (param.Document.ID != "" || param.Document.Link != "")
+	case models.TemplateFormatLocation:
+		return param.Type == models.TemplateParamLocation && param.Location != nil
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
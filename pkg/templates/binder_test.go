@@ -0,0 +1,307 @@
+package templates
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// positionalTemplate has a text header, a body with two parameters, a
+// dynamic URL button, a static URL button and a quick reply.
+var positionalTemplate = &models.Template{
+	Name:     "order_update",
+	Language: "en_US",
+	Components: []models.TemplateComponentDef{
+		{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Order {{1}}"},
+		{Type: models.TemplateDefBody, Text: "Hi {{1}}, your total is {{2}}."},
+		buttons(
+			urlButton("https://example.com/track/{{1}}"),
+			urlButton("https://example.com"),
+			quickReply("Stop"),
+		),
+	},
+}
+
+var namedTemplate = &models.Template{
+	Name:            "order_named",
+	Language:        "en_US",
+	ParameterFormat: "NAMED",
+	Components: []models.TemplateComponentDef{
+		{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Hi {{first_name}}"},
+		{Type: models.TemplateDefBody, Text: "Order {{order_id}} arrives {{delivery_date}}."},
+	},
+}
+
+var mediaTemplate = &models.Template{
+	Name:     "promo",
+	Language: "en_US",
+	Components: []models.TemplateComponentDef{
+		mediaHeader("IMAGE"),
+		{Type: models.TemplateDefLimitedTimeOffer, LimitedTimeOffer: &models.LimitedTimeOfferDef{Text: "Ends soon", HasExpiration: true}},
+		body("Save today"),
+		buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonCopyCode, Example: []string{"SAVE20"}},
+			models.TemplateButtonDef{Type: models.TemplateButtonFlow, Text: "Shop", FlowID: "123"},
+		),
+	},
+}
+
+var carouselTemplate = &models.Template{
+	Name:     "picks",
+	Language: "en_US",
+	Components: []models.TemplateComponentDef{
+		body("Our picks"),
+		{Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			{Components: []models.TemplateComponentDef{mediaHeader("IMAGE"), body("Item {{1}}", "A"), buttons(urlButton("https://example.com/{{1}}"))}},
+			{Components: []models.TemplateComponentDef{mediaHeader("IMAGE"), body("Item {{1}}", "B"), buttons(urlButton("https://example.com/{{1}}"))}},
+		}},
+	},
+}
+
+var otpTemplate = &models.Template{
+	Name:     "login_code",
+	Language: "en_US",
+	Category: "AUTHENTICATION",
+	Components: []models.TemplateComponentDef{
+		{Type: models.TemplateDefBody},
+		buttons(models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "COPY_CODE"}),
+	},
+}
+
+func TestNewBinder(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  *models.Template
+		wantField string
+		header    []string
+		body      []string
+	}{
+		{"positional", positionalTemplate, "", []string{"1"}, []string{"1", "2"}},
+		{"named", namedTemplate, "", []string{"first_name"}, []string{"order_id", "delivery_date"}},
+		{"positional order", &models.Template{Name: "t", Language: "en", Components: []models.TemplateComponentDef{body("{{2}} then {{1}}")}},
+			"", nil, []string{"1", "2"}},
+		{"nil", nil, "template", nil, nil},
+		{"no name", &models.Template{Language: "en"}, "template.name", nil, nil},
+		{"no language", &models.Template{Name: "t"}, "template.language", nil, nil},
+		{"invalid placeholder", &models.Template{Name: "t", Language: "en", Components: []models.TemplateComponentDef{body("Hi {{name}}")}},
+			"body", nil, nil},
+		{"invalid named placeholder", &models.Template{Name: "t", Language: "en", ParameterFormat: "NAMED", Components: []models.TemplateComponentDef{body("Hi {{1}}")}},
+			"body", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBinder(tt.template)
+			if tt.wantField != "" {
+				var verr *errors.ValidationError
+				if !stderrors.As(err, &verr) || verr.Field != tt.wantField {
+					t.Fatalf("NewBinder = %v, want a validation error for %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBinder: %v", err)
+			}
+			if !reflect.DeepEqual(b.HeaderParams(), tt.header) || !reflect.DeepEqual(b.BodyParams(), tt.body) {
+				t.Fatalf("params = %q, %q, want %q, %q", b.HeaderParams(), b.BodyParams(), tt.header, tt.body)
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	header := Text("A123")
+	image := Image(models.MediaContent{Link: "https://example.com/a.jpg"})
+	expires := time.UnixMilli(1700000000000)
+
+	tests := []struct {
+		name     string
+		template *models.Template
+		args     Args
+		want     string
+	}{
+		{"positional", positionalTemplate, Args{
+			Header:  &header,
+			Body:    []models.TemplateParameter{Text("Ann"), Currency("$10.00", "USD", 10000)},
+			Buttons: map[int]models.TemplateParameter{0: Text("A123"), 2: Payload("stop")},
+		}, `{"name":"order_update","language":{"code":"en_US"},"components":[` +
+			`{"type":"header","parameters":[{"type":"text","text":"A123"}]},` +
+			`{"type":"body","parameters":[{"type":"text","text":"Ann"},{"type":"currency","currency":{"fallback_value":"$10.00","code":"USD","amount_1000":10000}}]},` +
+			`{"type":"button","sub_type":"url","index":"0","parameters":[{"type":"text","text":"A123"}]},` +
+			`{"type":"button","sub_type":"quick_reply","index":"2","parameters":[{"type":"payload","payload":"stop"}]}]}`},
+		{"named", namedTemplate, Args{
+			Named: map[string]models.TemplateParameter{"first_name": Text("Ann"), "order_id": Text("A123"), "delivery_date": DateTime("Monday")},
+		}, `{"name":"order_named","language":{"code":"en_US"},"components":[` +
+			`{"type":"header","parameters":[{"type":"text","text":"Ann","parameter_name":"first_name"}]},` +
+			`{"type":"body","parameters":[{"type":"text","text":"A123","parameter_name":"order_id"},{"type":"date_time","date_time":{"fallback_value":"Monday"},"parameter_name":"delivery_date"}]}]}`},
+		{"media, offer, coupon and flow", mediaTemplate, Args{
+			Header:          &image,
+			OfferExpiration: expires,
+			Buttons:         map[int]models.TemplateParameter{0: CouponCode("SAVE20"), 1: FlowAction("token", nil)},
+		}, `{"name":"promo","language":{"code":"en_US"},"components":[` +
+			`{"type":"header","parameters":[{"type":"image","image":{"link":"https://example.com/a.jpg"}}]},` +
+			`{"type":"limited_time_offer","parameters":[{"type":"limited_time_offer","limited_time_offer":{"expiration_time_ms":1700000000000}}]},` +
+			`{"type":"button","sub_type":"copy_code","index":"0","parameters":[{"type":"coupon_code","coupon_code":"SAVE20"}]},` +
+			`{"type":"button","sub_type":"flow","index":"1","parameters":[{"type":"action","action":{"flow_token":"token"}}]}]}`},
+		{"carousel", carouselTemplate, Args{Cards: []Args{
+			{Header: &image, Body: []models.TemplateParameter{Text("A")}, Buttons: map[int]models.TemplateParameter{0: Text("a")}},
+			{Header: &image, Body: []models.TemplateParameter{Text("B")}, Buttons: map[int]models.TemplateParameter{0: Text("b")}},
+		}}, `{"name":"picks","language":{"code":"en_US"},"components":[{"type":"carousel","cards":[` +
+			`{"card_index":0,"components":[{"type":"header","parameters":[{"type":"image","image":{"link":"https://example.com/a.jpg"}}]},{"type":"body","parameters":[{"type":"text","text":"A"}]},{"type":"button","sub_type":"url","index":"0","parameters":[{"type":"text","text":"a"}]}]},` +
+			`{"card_index":1,"components":[{"type":"header","parameters":[{"type":"image","image":{"link":"https://example.com/a.jpg"}}]},{"type":"body","parameters":[{"type":"text","text":"B"}]},{"type":"button","sub_type":"url","index":"0","parameters":[{"type":"text","text":"b"}]}]}]}]}`},
+		{"OTP", otpTemplate, Args{Buttons: map[int]models.TemplateParameter{0: Text("123456")}},
+			`{"name":"login_code","language":{"code":"en_US"},"components":[` +
+				`{"type":"button","sub_type":"url","index":"0","parameters":[{"type":"text","text":"123456"}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBinder(tt.template)
+			if err != nil {
+				t.Fatalf("NewBinder: %v", err)
+			}
+			content, err := b.Bind(tt.args)
+			if err != nil {
+				t.Fatalf("Bind: %v", err)
+			}
+			got, err := json.Marshal(content)
+			if err != nil {
+				t.Fatalf("encoding content: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Bind =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	header := Text("A123")
+	emptyHeader := Text("")
+	image := Image(models.MediaContent{Link: "https://example.com/a.jpg"})
+	video := Video(models.MediaContent{ID: "1"})
+	validCard := Args{Header: &image, Body: []models.TemplateParameter{Text("A")}, Buttons: map[int]models.TemplateParameter{0: Text("a")}}
+
+	tests := []struct {
+		name     string
+		template *models.Template
+		args     Args
+		want     []string
+	}{
+		// Positional arguments
+		{"missing positional args", positionalTemplate, Args{}, []string{
+			"header: missing header text parameter {{1}}",
+			"body: template body expects 2 parameter(s), got 0",
+			"buttons[0]: missing URL suffix parameter for dynamic URL button",
+		}},
+		{"extra positional args", positionalTemplate, Args{
+			Header:  &header,
+			Body:    []models.TemplateParameter{Text("Ann"), Text("$10"), Text("extra")},
+			Buttons: map[int]models.TemplateParameter{0: Text("A123"), 1: Text("static")},
+			Named:   map[string]models.TemplateParameter{"name": Text("Ann")},
+		}, []string{
+			"body: template body expects 2 parameter(s), got 3",
+			"buttons[1]: template has no button accepting a parameter at this index",
+			"named: template uses positional placeholders",
+		}},
+		{"invalid positional args", positionalTemplate, Args{
+			Header:  &emptyHeader,
+			Body:    []models.TemplateParameter{Text(""), CouponCode("SAVE")},
+			Buttons: map[int]models.TemplateParameter{0: Payload("x"), 2: Text("stop")},
+		}, []string{
+			"header: header parameter must be non-empty text",
+			"body.{{1}}: text parameter cannot be empty",
+			`body.{{2}}: body parameters must be text, currency or date_time, got "coupon_code"`,
+			"buttons[0]: URL button parameter must be non-empty text",
+			"buttons[2]: quick reply button parameter must be a non-empty payload",
+		}},
+		{"incomplete currency and date_time", positionalTemplate, Args{
+			Header:  &header,
+			Body:    []models.TemplateParameter{Currency("", "USD", 100), {Type: models.TemplateParamDateTime}},
+			Buttons: map[int]models.TemplateParameter{0: Text("A123")},
+		}, []string{
+			"body.{{1}}: currency parameter requires code and fallback value",
+			"body.{{2}}: date_time parameter requires a fallback value",
+		}},
+
+		// Named arguments
+		{"missing named args", namedTemplate, Args{
+			Named: map[string]models.TemplateParameter{"first_name": Text("Ann")},
+		}, []string{
+			"named.order_id: missing body parameter",
+			"named.delivery_date: missing body parameter",
+		}},
+		{"extra named args", namedTemplate, Args{
+			Header: &header,
+			Body:   []models.TemplateParameter{Text("A123")},
+			Named:  map[string]models.TemplateParameter{"first_name": Text("Ann"), "order_id": Text("A123"), "delivery_date": Text("Monday"), "coupon": Text("SAVE")},
+		}, []string{
+			"header: template uses named placeholders; set the header value in Named",
+			"body: template uses named placeholders; set body values in Named",
+			"named.coupon: template has no placeholder with this name",
+		}},
+
+		// Media, offers and buttons
+		{"missing media args", mediaTemplate, Args{}, []string{
+			"header: missing IMAGE header parameter",
+			"offer_expiration: missing limited-time offer expiration",
+			"buttons[0]: missing coupon code parameter for copy code button",
+		}},
+		{"invalid media args", mediaTemplate, Args{
+			Header:          &video,
+			OfferExpiration: time.Now(),
+			Buttons:         map[int]models.TemplateParameter{0: CouponCode("SAVE20SAVE20SAVE20"), 1: Text("token")},
+		}, []string{
+			"header: header parameter must be image with an ID or link",
+			"buttons[0]: coupon code must be at most 15 characters",
+			"buttons[1]: flow button parameter must be a flow action",
+		}},
+		{"args for missing components", otpTemplate, Args{
+			Header:          &header,
+			OfferExpiration: time.Now(),
+			Cards:           []Args{validCard},
+		}, []string{
+			"header: template has no header",
+			"offer_expiration: template has no expiring limited-time offer",
+			"buttons[0]: missing one-time code parameter for OTP button",
+			"cards: template has no carousel",
+		}},
+
+		// Carousel cards
+		{"wrong card count", carouselTemplate, Args{Cards: []Args{validCard}}, []string{
+			"cards: template carousel has 2 card(s), got 1",
+		}},
+		{"invalid card args", carouselTemplate, Args{Cards: []Args{validCard, {Header: &video}}}, []string{
+			"cards[1].header: header parameter must be image with an ID or link",
+			"cards[1].body: template body expects 1 parameter(s), got 0",
+			"cards[1].buttons[0]: missing URL suffix parameter for dynamic URL button",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBinder(tt.template)
+			if err != nil {
+				t.Fatalf("NewBinder: %v", err)
+			}
+			_, err = b.Bind(tt.args)
+
+			var verrs errors.ValidationErrors
+			if !stderrors.As(err, &verrs) {
+				t.Fatalf("Bind = %v, want ValidationErrors", err)
+			}
+			var got []string
+			for _, e := range verrs {
+				got = append(got, e.Field+": "+e.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Bind errors =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
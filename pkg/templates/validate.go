@@ -0,0 +1,666 @@
+// Package templates provides local validation of message template definitions,
+// so mistakes are caught before a template is submitted for review.
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// Template definition limits
+const (
+	MaxNameLength        = 512
+	MaxHeaderTextLength  = 60
+	MaxBodyLength        = 1024
+	MaxFooterLength      = 60
+	MaxButtons           = 10
+	MaxURLButtons        = 2
+	MaxPhoneButtons      = 1
+	MaxButtonTextLength  = 25
+	MaxURLLength         = 2000
+	MaxPhoneNumberLength = 20
+
+	MaxCodeExpirationMinutes = 90
+	MaxAutofillTextLength    = 25
+	MaxOTPCodeLength         = 15
+
+	MinCarouselCards    = 2
+	MaxCarouselCards    = 10
+	MaxCardBodyLength   = 160
+	MaxCardButtons      = 2
+	MaxCouponCodeLength = 15
+
+	MaxLimitedTimeOfferTextLength = 16
+	MaxLimitedTimeOfferBodyLength = 600
+)
+
+var (
+	namePattern        = regexp.MustCompile(`^[a-z0-9_]+$`)
+	placeholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+)
+
+// ===============================
+// Placeholders
+// ===============================
+
+// Placeholders returns the positional parameter numbers used in text, in the
+// order they appear. Malformed placeholders (e.g. "{{name}}") are skipped.
+func Placeholders(text string) []int {
+	var nums []int
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if n, err := strconv.Atoi(strings.TrimSpace(m[1])); err == nil {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
+
+// ParamCount returns the number of distinct positional parameters in text.
+func ParamCount(text string) int {
+	seen := make(map[int]bool)
+	for _, n := range Placeholders(text) {
+		seen[n] = true
+	}
+	return len(seen)
+}
+
+// checkPlaceholders reports malformed placeholders. Positional placeholders
+// must be numbered from {{1}} to {{n}} without gaps; templates with the NAMED
+// parameter format use lowercase snake_case names such as {{first_name}}
+// instead. It returns the distinct parameters in order of first appearance.
+func checkPlaceholders(errs *errors.ValidationErrors, field, text string, named bool) []string {
+	var params []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		key := strings.TrimSpace(m[1])
+		if named {
+			if !namedPlaceholderPattern.MatchString(key) {
+				errs.Add(field, fmt.Sprintf("invalid placeholder %s: NAMED templates use lowercase names such as {{first_name}}", m[0]))
+				continue
+			}
+		} else if n, err := strconv.Atoi(key); err != nil || n < 1 {
+			errs.Add(field, fmt.Sprintf("invalid placeholder %s: use {{1}}, {{2}}, ...", m[0]))
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			params = append(params, key)
+		}
+	}
+
+	if !named {
+		for i := 1; i <= len(params); i++ {
+			if !seen[strconv.Itoa(i)] {
+				errs.Add(field, fmt.Sprintf("placeholders must be numbered sequentially from {{1}}: {{%d}} is missing", i))
+				break
+			}
+		}
+	}
+
+	return params
+}
+
+// checkNamedExamples reports named parameters without an example value and
+// example values for parameters the text does not use.
+func checkNamedExamples(errs *errors.ValidationErrors, field string, params []string, examples []models.NamedParamExample) {
+	given := make(map[string]bool, len(examples))
+	for _, ex := range examples {
+		given[ex.ParamName] = true
+	}
+	for _, param := range params {
+		if !given[param] {
+			errs.Add(field, fmt.Sprintf("no example value for placeholder {{%s}}", param))
+		}
+	}
+
+	used := make(map[string]bool, len(params))
+	for _, param := range params {
+		used[param] = true
+	}
+	for _, ex := range examples {
+		if !used[ex.ParamName] {
+			errs.Add(field, fmt.Sprintf("example value for unused placeholder {{%s}}", ex.ParamName))
+		}
+	}
+}
+
+// ===============================
+// Validation
+// ===============================
+
+// Validate checks a template definition against the component rules enforced
+// by template review. All violations are returned together as
+// errors.ValidationErrors; nil means the definition looks valid.
+func Validate(t *models.Template) error {
+	var errs errors.ValidationErrors
+	if t == nil {
+		errs.Add("template", "template is required")
+		return errs
+	}
+
+	validateName(&errs, t.Name)
+
+	category := strings.ToUpper(t.Category)
+	switch category {
+	case models.TemplateCategoryMarketing, models.TemplateCategoryUtility, models.TemplateCategoryAuthentication:
+	case "":
+		errs.Add("category", "category is required")
+	default:
+		errs.Add("category", fmt.Sprintf("unknown category %q", t.Category))
+	}
+
+	if t.Language == "" {
+		errs.Add("language", "language is required")
+	}
+
+	switch strings.ToUpper(t.ParameterFormat) {
+	case "", models.TemplateParameterFormatPositional, models.TemplateParameterFormatNamed:
+	default:
+		errs.Add("parameter_format", fmt.Sprintf("unknown parameter format %q", t.ParameterFormat))
+	}
+
+	ValidateComponents(&errs, category, t.ParameterFormat, t.Components)
+
+	return errs.ErrOrNil()
+}
+
+// ValidateComponents checks a list of component definitions for the given
+// category and parameter format (POSITIONAL if empty) and appends any
+// violations to errs.
+func ValidateComponents(errs *errors.ValidationErrors, category, parameterFormat string, components []models.TemplateComponentDef) {
+	named := strings.EqualFold(parameterFormat, models.TemplateParameterFormatNamed)
+	seen := make(map[string]bool)
+	byType := make(map[string]*models.TemplateComponentDef)
+	for i, comp := range components {
+		byType[strings.ToUpper(comp.Type)] = &components[i]
+		field := fmt.Sprintf("components[%d]", i)
+		compType := strings.ToUpper(comp.Type)
+
+		if seen[compType] {
+			errs.Add(field, fmt.Sprintf("duplicate %s component", compType))
+		}
+		seen[compType] = true
+
+		switch compType {
+		case models.TemplateDefHeader:
+			validateHeader(errs, field, category, named, &comp)
+		case models.TemplateDefBody:
+			validateBody(errs, field, category, named, &comp)
+		case models.TemplateDefFooter:
+			validateFooter(errs, field, category, &comp)
+		case models.TemplateDefButtons:
+			validateButtons(errs, field, category, comp.Buttons)
+		case models.TemplateDefCarousel:
+			validateCarousel(errs, field, category, named, &comp)
+		case models.TemplateDefLimitedTimeOffer:
+			validateLimitedTimeOffer(errs, field, category, &comp)
+		default:
+			errs.Add(field+".type", fmt.Sprintf("unknown component type %q", comp.Type))
+		}
+	}
+
+	if !seen[models.TemplateDefBody] {
+		errs.Add("components", "a BODY component is required")
+	}
+	if strings.ToUpper(category) == models.TemplateCategoryAuthentication && !seen[models.TemplateDefButtons] {
+		errs.Add("components", "AUTHENTICATION templates require an OTP button")
+	}
+
+	if seen[models.TemplateDefCarousel] {
+		for compType := range seen {
+			if compType != models.TemplateDefBody && compType != models.TemplateDefCarousel {
+				errs.Add("components", fmt.Sprintf("carousel templates cannot have a top-level %s component", compType))
+			}
+		}
+	}
+
+	if seen[models.TemplateDefLimitedTimeOffer] {
+		validateLimitedTimeOfferTemplate(errs, byType)
+	}
+}
+
+func validateName(errs *errors.ValidationErrors, name string) {
+	switch {
+	case name == "":
+		errs.Add("name", "name is required")
+	case len(name) > MaxNameLength:
+		errs.Add("name", fmt.Sprintf("name must be at most %d characters", MaxNameLength))
+	case !namePattern.MatchString(name):
+		errs.Add("name", "name may only contain lowercase letters, numbers and underscores")
+	}
+}
+
+func validateHeader(errs *errors.ValidationErrors, field, category string, named bool, comp *models.TemplateComponentDef) {
+	if strings.ToUpper(category) == models.TemplateCategoryAuthentication {
+		errs.Add(field, "AUTHENTICATION templates cannot have a header")
+		return
+	}
+
+	format := strings.ToUpper(comp.Format)
+	switch format {
+	case models.TemplateFormatText:
+		if comp.Text == "" {
+			errs.Add(field+".text", "text header requires text")
+			return
+		}
+		if utf8.RuneCountInString(comp.Text) > MaxHeaderTextLength {
+			errs.Add(field+".text", fmt.Sprintf("header text must be at most %d characters", MaxHeaderTextLength))
+		}
+		params := checkPlaceholders(errs, field+".text", comp.Text, named)
+		n := len(params)
+		if n > 1 {
+			errs.Add(field+".text", "header text may contain at most one placeholder")
+		}
+		switch {
+		case n == 0:
+		case named:
+			var examples []models.NamedParamExample
+			if comp.Example != nil {
+				examples = comp.Example.HeaderTextNamedParams
+			}
+			checkNamedExamples(errs, field+".example.header_text_named_params", params, examples)
+		case comp.Example == nil || len(comp.Example.HeaderText) != n:
+			errs.Add(field+".example.header_text", fmt.Sprintf("header uses %d placeholder(s) but example provides %d value(s)", n, exampleHeaderTextLen(comp.Example)))
+		}
+
+	case models.TemplateFormatImage, models.TemplateFormatVideo, models.TemplateFormatDocument:
+		if comp.Example == nil || len(comp.Example.HeaderHandle) == 0 {
+			errs.Add(field+".example.header_handle", fmt.Sprintf("%s header requires an uploaded media handle example", format))
+		}
+		if comp.Text != "" {
+			errs.Add(field+".text", fmt.Sprintf("%s header cannot have text", format))
+		}
+
+	case models.TemplateFormatLocation:
+		if comp.Text != "" {
+			errs.Add(field+".text", "LOCATION header cannot have text")
+		}
+
+	case "":
+		errs.Add(field+".format", "header format is required")
+	default:
+		errs.Add(field+".format", fmt.Sprintf("unknown header format %q", comp.Format))
+	}
+}
+
+func validateBody(errs *errors.ValidationErrors, field, category string, named bool, comp *models.TemplateComponentDef) {
+	// Authentication bodies are preset by WhatsApp
+	if strings.ToUpper(category) == models.TemplateCategoryAuthentication {
+		if comp.Text != "" {
+			errs.Add(field+".text", "AUTHENTICATION template body text is preset and cannot be set")
+		}
+		return
+	}
+	if comp.AddSecurityRecommendation {
+		errs.Add(field+".add_security_recommendation", "only AUTHENTICATION templates can add a security recommendation")
+	}
+
+	if comp.Text == "" {
+		errs.Add(field+".text", "body text is required")
+		return
+	}
+
+	if utf8.RuneCountInString(comp.Text) > MaxBodyLength {
+		errs.Add(field+".text", fmt.Sprintf("body text must be at most %d characters", MaxBodyLength))
+	}
+
+	params := checkPlaceholders(errs, field+".text", comp.Text, named)
+	n := len(params)
+	if n == 0 {
+		return
+	}
+
+	trimmed := strings.TrimSpace(comp.Text)
+	matches := placeholderPattern.FindAllStringIndex(trimmed, -1)
+	if matches[0][0] == 0 {
+		errs.Add(field+".text", "body text cannot start with a placeholder")
+	}
+	if matches[len(matches)-1][1] == len(trimmed) {
+		errs.Add(field+".text", "body text cannot end with a placeholder")
+	}
+
+	if named {
+		var examples []models.NamedParamExample
+		if comp.Example != nil {
+			examples = comp.Example.BodyTextNamedParams
+		}
+		checkNamedExamples(errs, field+".example.body_text_named_params", params, examples)
+		return
+	}
+
+	if comp.Example == nil || len(comp.Example.BodyText) == 0 {
+		errs.Add(field+".example.body_text", fmt.Sprintf("body uses %d placeholder(s) but no example values are provided", n))
+		return
+	}
+	if got := len(comp.Example.BodyText[0]); got != n {
+		errs.Add(field+".example.body_text", fmt.Sprintf("body uses %d placeholder(s) but example provides %d value(s)", n, got))
+	}
+}
+
+func validateFooter(errs *errors.ValidationErrors, field, category string, comp *models.TemplateComponentDef) {
+	if strings.ToUpper(category) == models.TemplateCategoryAuthentication {
+		if comp.Text != "" {
+			errs.Add(field+".text", "AUTHENTICATION template footer text is preset and cannot be set")
+		}
+		if comp.CodeExpirationMinutes < 0 || comp.CodeExpirationMinutes > MaxCodeExpirationMinutes {
+			errs.Add(field+".code_expiration_minutes", fmt.Sprintf("code expiration must be between 1 and %d minutes", MaxCodeExpirationMinutes))
+		}
+		return
+	}
+	if comp.CodeExpirationMinutes != 0 {
+		errs.Add(field+".code_expiration_minutes", "only AUTHENTICATION templates can set a code expiration")
+	}
+
+	if comp.Text == "" {
+		errs.Add(field+".text", "footer text is required")
+		return
+	}
+	if utf8.RuneCountInString(comp.Text) > MaxFooterLength {
+		errs.Add(field+".text", fmt.Sprintf("footer text must be at most %d characters", MaxFooterLength))
+	}
+	if placeholderPattern.MatchString(comp.Text) {
+		errs.Add(field+".text", "footer text cannot contain placeholders")
+	}
+}
+
+func validateButtons(errs *errors.ValidationErrors, field, category string, buttons []models.TemplateButtonDef) {
+	if len(buttons) == 0 {
+		errs.Add(field+".buttons", "BUTTONS component requires at least one button")
+		return
+	}
+	if len(buttons) > MaxButtons {
+		errs.Add(field+".buttons", fmt.Sprintf("at most %d buttons are allowed", MaxButtons))
+	}
+
+	counts := make(map[string]int)
+	// Quick replies must be grouped together, not interleaved with other buttons
+	groupSwitches := 0
+	prevQuickReply := false
+
+	for i, btn := range buttons {
+		bField := fmt.Sprintf("%s.buttons[%d]", field, i)
+		btnType := strings.ToUpper(btn.Type)
+		counts[btnType]++
+
+		isQuickReply := btnType == models.TemplateButtonQuickReply
+		if i > 0 && isQuickReply != prevQuickReply {
+			groupSwitches++
+		}
+		prevQuickReply = isQuickReply
+
+		if btnType != models.TemplateButtonOTP && btnType != models.TemplateButtonCopyCode {
+			switch {
+			case btn.Text == "":
+				errs.Add(bField+".text", "button text is required")
+			case utf8.RuneCountInString(btn.Text) > MaxButtonTextLength:
+				errs.Add(bField+".text", fmt.Sprintf("button text must be at most %d characters", MaxButtonTextLength))
+			}
+		}
+
+		switch btnType {
+		case models.TemplateButtonQuickReply:
+		case models.TemplateButtonURL:
+			validateURLButton(errs, bField, &btn)
+		case models.TemplateButtonPhoneNumber:
+			switch {
+			case btn.PhoneNumber == "":
+				errs.Add(bField+".phone_number", "phone number is required")
+			case len(btn.PhoneNumber) > MaxPhoneNumberLength:
+				errs.Add(bField+".phone_number", fmt.Sprintf("phone number must be at most %d characters", MaxPhoneNumberLength))
+			}
+		case models.TemplateButtonOTP:
+			validateOTPButton(errs, bField, &btn)
+		case models.TemplateButtonCopyCode:
+			switch {
+			case len(btn.Example) == 0 || btn.Example[0] == "":
+				errs.Add(bField+".example", "copy code button requires an example coupon code")
+			case utf8.RuneCountInString(btn.Example[0]) > MaxCouponCodeLength:
+				errs.Add(bField+".example", fmt.Sprintf("coupon code must be at most %d characters", MaxCouponCodeLength))
+			}
+		case models.TemplateButtonFlow:
+			validateFlowButton(errs, bField, &btn)
+		default:
+			errs.Add(bField+".type", fmt.Sprintf("unknown button type %q", btn.Type))
+		}
+	}
+
+	if groupSwitches > 1 {
+		errs.Add(field+".buttons", "quick reply buttons must be grouped together")
+	}
+	if counts[models.TemplateButtonURL] > MaxURLButtons {
+		errs.Add(field+".buttons", fmt.Sprintf("at most %d URL buttons are allowed", MaxURLButtons))
+	}
+	if counts[models.TemplateButtonPhoneNumber] > MaxPhoneButtons {
+		errs.Add(field+".buttons", fmt.Sprintf("at most %d phone number button is allowed", MaxPhoneButtons))
+	}
+	if counts[models.TemplateButtonFlow] > 1 {
+		errs.Add(field+".buttons", "at most 1 flow button is allowed")
+	}
+	if counts[models.TemplateButtonCopyCode] > 1 {
+		errs.Add(field+".buttons", "at most 1 copy code button is allowed")
+	}
+	if counts[models.TemplateButtonCopyCode] > 0 && strings.ToUpper(category) != models.TemplateCategoryMarketing {
+		errs.Add(field+".buttons", "copy code buttons are only allowed in MARKETING templates")
+	}
+
+	isAuth := strings.ToUpper(category) == models.TemplateCategoryAuthentication
+	switch {
+	case isAuth && (len(buttons) != 1 || counts[models.TemplateButtonOTP] != 1):
+		errs.Add(field+".buttons", "AUTHENTICATION templates require exactly one OTP button")
+	case !isAuth && counts[models.TemplateButtonOTP] > 0:
+		errs.Add(field+".buttons", "OTP buttons are only allowed in AUTHENTICATION templates")
+	}
+}
+
+func validateURLButton(errs *errors.ValidationErrors, field string, btn *models.TemplateButtonDef) {
+	if btn.URL == "" {
+		errs.Add(field+".url", "URL is required")
+		return
+	}
+	if len(btn.URL) > MaxURLLength {
+		errs.Add(field+".url", fmt.Sprintf("URL must be at most %d characters", MaxURLLength))
+	}
+	if !strings.HasPrefix(btn.URL, "https://") && !strings.HasPrefix(btn.URL, "http://") {
+		errs.Add(field+".url", "URL must start with http:// or https://")
+	}
+
+	matches := placeholderPattern.FindAllStringIndex(btn.URL, -1)
+	if len(matches) == 0 {
+		return
+	}
+	if len(matches) > 1 {
+		errs.Add(field+".url", "URL may contain at most one placeholder")
+	}
+	if last := matches[len(matches)-1]; last[1] != len(btn.URL) {
+		errs.Add(field+".url", "URL placeholder must be at the end of the URL")
+	}
+	if nums := Placeholders(btn.URL); len(nums) > 0 && nums[0] != 1 {
+		errs.Add(field+".url", "URL placeholder must be {{1}}")
+	}
+	if len(btn.Example) == 0 {
+		errs.Add(field+".example", "URL with a placeholder requires an example URL")
+	}
+}
+
+func validateOTPButton(errs *errors.ValidationErrors, field string, btn *models.TemplateButtonDef) {
+	otpType := strings.ToUpper(btn.OTPType)
+	switch otpType {
+	case models.OTPTypeCopyCode:
+		return
+	case models.OTPTypeOneTap, models.OTPTypeZeroTap:
+	case "":
+		errs.Add(field+".otp_type", "OTP button requires an otp_type")
+		return
+	default:
+		errs.Add(field+".otp_type", fmt.Sprintf("unknown otp_type %q", btn.OTPType))
+		return
+	}
+
+	if btn.PackageName == "" && len(btn.SupportedApps) == 0 {
+		errs.Add(field+".package_name", fmt.Sprintf("%s buttons require the Android package name", otpType))
+	}
+	if btn.PackageName != "" && btn.SignatureHash == "" {
+		errs.Add(field+".signature_hash", fmt.Sprintf("%s buttons require the app signature hash", otpType))
+	}
+	for i, app := range btn.SupportedApps {
+		if app.PackageName == "" || app.SignatureHash == "" {
+			errs.Add(fmt.Sprintf("%s.supported_apps[%d]", field, i), "package name and signature hash are required")
+		}
+	}
+	if utf8.RuneCountInString(btn.AutofillText) > MaxAutofillTextLength {
+		errs.Add(field+".autofill_text", fmt.Sprintf("autofill text must be at most %d characters", MaxAutofillTextLength))
+	}
+	if otpType == models.OTPTypeZeroTap && !btn.ZeroTapTermsAccepted {
+		errs.Add(field+".zero_tap_terms_accepted", "zero-tap terms must be accepted")
+	}
+}
+
+func validateFlowButton(errs *errors.ValidationErrors, field string, btn *models.TemplateButtonDef) {
+	set := 0
+	for _, v := range []string{btn.FlowID, btn.FlowName, btn.FlowJSON} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		errs.Add(field+".flow_id", "flow button requires exactly one of flow_id, flow_name or flow_json")
+	}
+
+	switch models.FlowAction(strings.ToLower(btn.FlowAction)) {
+	case "", models.FlowActionNavigate, models.FlowActionDataExchange:
+	default:
+		errs.Add(field+".flow_action", fmt.Sprintf("unknown flow_action %q", btn.FlowAction))
+	}
+	if btn.NavigateScreen != "" && models.FlowAction(strings.ToLower(btn.FlowAction)) == models.FlowActionDataExchange {
+		errs.Add(field+".navigate_screen", "navigate_screen is only allowed with the navigate action")
+	}
+}
+
+func validateCarousel(errs *errors.ValidationErrors, field, category string, named bool, comp *models.TemplateComponentDef) {
+	if len(comp.Cards) < MinCarouselCards || len(comp.Cards) > MaxCarouselCards {
+		errs.Add(field+".cards", fmt.Sprintf("carousel must have between %d and %d cards", MinCarouselCards, MaxCarouselCards))
+	}
+
+	var headerFormat string
+	var buttonTypes []string
+	for i, card := range comp.Cards {
+		cField := fmt.Sprintf("%s.cards[%d]", field, i)
+
+		var format string
+		var types []string
+		hasBody := false
+		for j, cardComp := range card.Components {
+			ccField := fmt.Sprintf("%s.components[%d]", cField, j)
+			switch strings.ToUpper(cardComp.Type) {
+			case models.TemplateDefHeader:
+				format = strings.ToUpper(cardComp.Format)
+				if format != models.TemplateFormatImage && format != models.TemplateFormatVideo {
+					errs.Add(ccField+".format", "card header must be IMAGE or VIDEO")
+					continue
+				}
+				validateHeader(errs, ccField, category, named, &cardComp)
+			case models.TemplateDefBody:
+				hasBody = true
+				validateBody(errs, ccField, category, named, &cardComp)
+				if utf8.RuneCountInString(cardComp.Text) > MaxCardBodyLength {
+					errs.Add(ccField+".text", fmt.Sprintf("card body text must be at most %d characters", MaxCardBodyLength))
+				}
+			case models.TemplateDefButtons:
+				validateButtons(errs, ccField, category, cardComp.Buttons)
+				if len(cardComp.Buttons) > MaxCardButtons {
+					errs.Add(ccField+".buttons", fmt.Sprintf("cards may have at most %d buttons", MaxCardButtons))
+				}
+				for _, btn := range cardComp.Buttons {
+					btnType := strings.ToUpper(btn.Type)
+					types = append(types, btnType)
+					if btnType != models.TemplateButtonQuickReply && btnType != models.TemplateButtonURL && btnType != models.TemplateButtonPhoneNumber {
+						errs.Add(ccField+".buttons", fmt.Sprintf("%s buttons are not allowed on carousel cards", btnType))
+					}
+				}
+			default:
+				errs.Add(ccField+".type", fmt.Sprintf("%s components are not allowed on carousel cards", cardComp.Type))
+			}
+		}
+
+		if format == "" {
+			errs.Add(cField, "card requires an IMAGE or VIDEO header")
+		}
+		if !hasBody {
+			errs.Add(cField, "card requires a body")
+		}
+		if len(types) == 0 {
+			errs.Add(cField, "card requires at least one button")
+		}
+
+		// Every card must match the first card's header format and buttons
+		if i == 0 {
+			headerFormat, buttonTypes = format, types
+			continue
+		}
+		if format != headerFormat {
+			errs.Add(cField, "all cards must use the same header format")
+		}
+		if strings.Join(types, ",") != strings.Join(buttonTypes, ",") {
+			errs.Add(cField, "all cards must have the same number and types of buttons")
+		}
+	}
+}
+
+func validateLimitedTimeOffer(errs *errors.ValidationErrors, field, category string, comp *models.TemplateComponentDef) {
+	if strings.ToUpper(category) != models.TemplateCategoryMarketing {
+		errs.Add(field, "limited-time offers are only allowed in MARKETING templates")
+	}
+
+	offer := comp.LimitedTimeOffer
+	switch {
+	case offer == nil || offer.Text == "":
+		errs.Add(field+".limited_time_offer.text", "offer text is required")
+	case utf8.RuneCountInString(offer.Text) > MaxLimitedTimeOfferTextLength:
+		errs.Add(field+".limited_time_offer.text", fmt.Sprintf("offer text must be at most %d characters", MaxLimitedTimeOfferTextLength))
+	}
+}
+
+// validateLimitedTimeOfferTemplate checks the rules that limited-time-offer
+// templates place on their other components.
+func validateLimitedTimeOfferTemplate(errs *errors.ValidationErrors, byType map[string]*models.TemplateComponentDef) {
+	if _, ok := byType[models.TemplateDefFooter]; ok {
+		errs.Add("components", "limited-time-offer templates cannot have a footer")
+	}
+
+	if header, ok := byType[models.TemplateDefHeader]; ok {
+		format := strings.ToUpper(header.Format)
+		if format != models.TemplateFormatImage && format != models.TemplateFormatVideo {
+			errs.Add("components", "limited-time-offer template header must be IMAGE or VIDEO")
+		}
+	}
+
+	if body, ok := byType[models.TemplateDefBody]; ok && utf8.RuneCountInString(body.Text) > MaxLimitedTimeOfferBodyLength {
+		errs.Add("components", fmt.Sprintf("limited-time-offer body text must be at most %d characters", MaxLimitedTimeOfferBodyLength))
+	}
+
+	hasURL := false
+	if buttons, ok := byType[models.TemplateDefButtons]; ok {
+		for _, btn := range buttons.Buttons {
+			if strings.EqualFold(btn.Type, models.TemplateButtonURL) {
+				hasURL = true
+			}
+		}
+	}
+	if !hasURL {
+		errs.Add("components", "limited-time-offer templates require a URL button")
+	}
+}
+
+// exampleHeaderTextLen returns the number of header text examples.
+func exampleHeaderTextLen(ex *models.TemplateExample) int {
+	if ex == nil {
+		return 0
+	}
+	return len(ex.HeaderText)
+}
@@ -0,0 +1,285 @@
+package templates
+
+import (
+	stderrors "errors"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/errors"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// fields returns the fields of the validation errors in err, in order.
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verrs errors.ValidationErrors
+	if !stderrors.As(err, &verrs) {
+		t.Fatalf("error %v is not a ValidationErrors", err)
+	}
+	var fields []string
+	for _, e := range verrs {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
+
+func body(text string, examples ...string) models.TemplateComponentDef {
+	comp := models.TemplateComponentDef{Type: models.TemplateDefBody, Text: text}
+	if len(examples) > 0 {
+		comp.Example = &models.TemplateExample{BodyText: [][]string{examples}}
+	}
+	return comp
+}
+
+func buttons(btns ...models.TemplateButtonDef) models.TemplateComponentDef {
+	return models.TemplateComponentDef{Type: models.TemplateDefButtons, Buttons: btns}
+}
+
+func mediaHeader(format string) models.TemplateComponentDef {
+	return models.TemplateComponentDef{Type: models.TemplateDefHeader, Format: format, Example: &models.TemplateExample{HeaderHandle: []string{"4::aW"}}}
+}
+
+func quickReply(text string) models.TemplateButtonDef {
+	return models.TemplateButtonDef{Type: models.TemplateButtonQuickReply, Text: text}
+}
+
+func urlButton(url string) models.TemplateButtonDef {
+	return models.TemplateButtonDef{Type: models.TemplateButtonURL, Text: "Shop", URL: url}
+}
+
+func card(headerFormat string, btns ...models.TemplateButtonDef) models.TemplateCardDef {
+	return models.TemplateCardDef{Components: []models.TemplateComponentDef{
+		mediaHeader(headerFormat),
+		body("Fresh deals"),
+		buttons(btns...),
+	}}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   *models.Template
+		wantFields []string
+	}{
+		{"valid", &models.Template{Name: "order_update", Category: "UTILITY", Language: "en_US", Components: []models.TemplateComponentDef{
+			body("Your order {{1}} has shipped.", "A123"),
+		}}, nil},
+		{"nil", nil, []string{"template"}},
+		{"template fields", &models.Template{Name: "Order Update", Category: "PROMO", ParameterFormat: "NUMBERED", Components: []models.TemplateComponentDef{
+			body("Hello"),
+		}}, []string{"name", "category", "language", "parameter_format"}},
+		{"components are validated", &models.Template{Name: "order_update", Category: "UTILITY", Language: "en_US", Components: []models.TemplateComponentDef{
+			{Type: models.TemplateDefFooter, Text: "Thanks"},
+		}}, []string{"components"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, Validate(tt.template)); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Fatalf("error fields = %q, want %q", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidateComponents(t *testing.T) {
+	tests := []struct {
+		name            string
+		category        string
+		parameterFormat string
+		components      []models.TemplateComponentDef
+		wantFields      []string
+	}{
+		// Structure
+		{"body required", "UTILITY", "", []models.TemplateComponentDef{{Type: models.TemplateDefFooter, Text: "Thanks"}},
+			[]string{"components"}},
+		{"duplicate component", "UTILITY", "", []models.TemplateComponentDef{body("Hi"), body("Hello")},
+			[]string{"components[1]"}},
+		{"unknown component", "UTILITY", "", []models.TemplateComponentDef{body("Hi"), {Type: "SIDEBAR"}},
+			[]string{"components[1].type"}},
+
+		// Headers
+		{"text header", "MARKETING", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Hi {{1}}", Example: &models.TemplateExample{HeaderText: []string{"Ann"}}}, body("Hello"),
+		}, nil},
+		{"text header without example", "MARKETING", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Hi {{1}}"}, body("Hello"),
+		}, []string{"components[0].example.header_text"}},
+		{"text header with two placeholders", "MARKETING", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "{{1}} and {{2}}", Example: &models.TemplateExample{HeaderText: []string{"a", "b"}}}, body("Hello"),
+		}, []string{"components[0].text"}},
+		{"text header too long", "MARKETING", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: string(make([]rune, MaxHeaderTextLength+1))}, body("Hello"),
+		}, []string{"components[0].text"}},
+		{"image header", "MARKETING", "", []models.TemplateComponentDef{mediaHeader("IMAGE"), body("Hello")}, nil},
+		{"document header without handle", "MARKETING", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "DOCUMENT", Text: "file"}, body("Hello"),
+		}, []string{"components[0].example.header_handle", "components[0].text"}},
+		{"location header", "UTILITY", "", []models.TemplateComponentDef{{Type: models.TemplateDefHeader, Format: "LOCATION"}, body("Hello")}, nil},
+		{"missing header format", "UTILITY", "", []models.TemplateComponentDef{{Type: models.TemplateDefHeader}, body("Hello")},
+			[]string{"components[0].format"}},
+		{"unknown header format", "UTILITY", "", []models.TemplateComponentDef{{Type: models.TemplateDefHeader, Format: "AUDIO"}, body("Hello")},
+			[]string{"components[0].format"}},
+
+		// Bodies and footers
+		{"placeholder at body edges", "UTILITY", "", []models.TemplateComponentDef{body("{{1}} shipped {{2}}", "A", "B")},
+			[]string{"components[0].text", "components[0].text"}},
+		{"body example count", "UTILITY", "", []models.TemplateComponentDef{body("Order {{1}} for {{2}}.", "A")},
+			[]string{"components[0].example.body_text"}},
+		{"footer with placeholder", "UTILITY", "", []models.TemplateComponentDef{body("Hello"), {Type: models.TemplateDefFooter, Text: "Ref {{1}}"}},
+			[]string{"components[1].text"}},
+
+		// Buttons
+		{"quick replies and URL", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			quickReply("Yes"), quickReply("No"), urlButton("https://example.com/{{1}}"),
+		)}, []string{"components[1].buttons[2].example"}},
+		{"interleaved quick replies", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			quickReply("Yes"), urlButton("https://example.com"), quickReply("No"),
+		)}, []string{"components[1].buttons"}},
+		{"URL placeholder not at end", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonURL, Text: "Track", URL: "https://example.com/{{1}}/track", Example: []string{"https://example.com/1/track"}},
+		)}, []string{"components[1].buttons[0].url"}},
+		{"copy code outside marketing", "UTILITY", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonCopyCode, Example: []string{"SAVE10"}},
+		)}, []string{"components[1].buttons"}},
+
+		// Flow buttons
+		{"flow button", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonFlow, Text: "Book", FlowID: "123", FlowAction: "navigate", NavigateScreen: "WELCOME"},
+		)}, nil},
+		{"flow button with two flow sources", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonFlow, Text: "Book", FlowID: "123", FlowName: "booking"},
+		)}, []string{"components[1].buttons[0].flow_id"}},
+		{"flow button screen with data exchange", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonFlow, Text: "Book", FlowName: "booking", FlowAction: "data_exchange", NavigateScreen: "WELCOME"},
+		)}, []string{"components[1].buttons[0].navigate_screen"}},
+		{"unknown flow action", "MARKETING", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonFlow, Text: "Book", FlowID: "123", FlowAction: "open"},
+		)}, []string{"components[1].buttons[0].flow_action"}},
+
+		// Authentication templates
+		{"copy code OTP", "AUTHENTICATION", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefBody, AddSecurityRecommendation: true},
+			{Type: models.TemplateDefFooter, CodeExpirationMinutes: 10},
+			buttons(models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "COPY_CODE"}),
+		}, nil},
+		{"one tap OTP without app", "AUTHENTICATION", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefBody},
+			buttons(models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "ONE_TAP"}),
+		}, []string{"components[1].buttons[0].package_name"}},
+		{"zero tap OTP without terms", "AUTHENTICATION", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefBody},
+			buttons(models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "ZERO_TAP", PackageName: "com.example", SignatureHash: "K8a"}),
+		}, []string{"components[1].buttons[0].zero_tap_terms_accepted"}},
+		{"OTP template without button", "AUTHENTICATION", "", []models.TemplateComponentDef{{Type: models.TemplateDefBody}},
+			[]string{"components"}},
+		{"OTP template with custom text", "AUTHENTICATION", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Code"},
+			{Type: models.TemplateDefBody, Text: "Your code is {{1}}"},
+			{Type: models.TemplateDefFooter, CodeExpirationMinutes: 120},
+			buttons(models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "COPY_CODE"}),
+		}, []string{"components[0]", "components[1].text", "components[2].code_expiration_minutes"}},
+		{"OTP button outside authentication", "UTILITY", "", []models.TemplateComponentDef{body("Hello"), buttons(
+			models.TemplateButtonDef{Type: models.TemplateButtonOTP, OTPType: "COPY_CODE"},
+		)}, []string{"components[1].buttons"}},
+
+		// Carousels
+		{"carousel", "MARKETING", "", []models.TemplateComponentDef{body("Our picks"), {Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			card("IMAGE", quickReply("More")), card("IMAGE", quickReply("More")),
+		}}}, nil},
+		{"carousel with one card", "MARKETING", "", []models.TemplateComponentDef{body("Our picks"), {Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			card("IMAGE", quickReply("More")),
+		}}}, []string{"components[1].cards"}},
+		{"mismatched carousel cards", "MARKETING", "", []models.TemplateComponentDef{body("Our picks"), {Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			card("IMAGE", quickReply("More")), card("VIDEO", urlButton("https://example.com")),
+		}}}, []string{"components[1].cards[1]", "components[1].cards[1]"}},
+		{"carousel card with text header", "MARKETING", "", []models.TemplateComponentDef{body("Our picks"), {Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			card("IMAGE", quickReply("More")),
+			{Components: []models.TemplateComponentDef{{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Hi"}, body("Fresh deals"), buttons(quickReply("More"))}},
+		}}}, []string{"components[1].cards[1].components[0].format", "components[1].cards[1]"}},
+		{"carousel with footer", "MARKETING", "", []models.TemplateComponentDef{body("Our picks"), {Type: models.TemplateDefCarousel, Cards: []models.TemplateCardDef{
+			card("IMAGE", quickReply("More")), card("IMAGE", quickReply("More")),
+		}}, {Type: models.TemplateDefFooter, Text: "Thanks"}}, []string{"components"}},
+
+		// Limited-time offers
+		{"limited-time offer", "MARKETING", "", []models.TemplateComponentDef{
+			mediaHeader("IMAGE"),
+			{Type: models.TemplateDefLimitedTimeOffer, LimitedTimeOffer: &models.LimitedTimeOfferDef{Text: "Expiring soon", HasExpiration: true}},
+			body("Save 20% today"),
+			buttons(models.TemplateButtonDef{Type: models.TemplateButtonCopyCode, Example: []string{"SAVE20"}}, urlButton("https://example.com")),
+		}, nil},
+		{"limited-time offer rules", "UTILITY", "", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Sale"},
+			{Type: models.TemplateDefLimitedTimeOffer, LimitedTimeOffer: &models.LimitedTimeOfferDef{Text: "This offer ends very soon"}},
+			body("Save 20% today"),
+			{Type: models.TemplateDefFooter, Text: "Thanks"},
+		}, []string{"components[1]", "components[1].limited_time_offer.text", "components", "components", "components"}},
+
+		// Parameter formats
+		{"positional gap", "UTILITY", "POSITIONAL", []models.TemplateComponentDef{body("Order {{1}} arrives {{3}}.", "A", "B")},
+			[]string{"components[0].text"}},
+		{"positional with names", "UTILITY", "", []models.TemplateComponentDef{body("Hello {{name}}.", "Ann")},
+			[]string{"components[0].text"}},
+		{"named", "UTILITY", "NAMED", []models.TemplateComponentDef{
+			{Type: models.TemplateDefHeader, Format: "TEXT", Text: "Hi {{first_name}}", Example: &models.TemplateExample{
+				HeaderTextNamedParams: []models.NamedParamExample{{ParamName: "first_name", Example: "Ann"}},
+			}},
+			{Type: models.TemplateDefBody, Text: "Order {{order_id}} ships {{ship_date}}.", Example: &models.TemplateExample{
+				BodyTextNamedParams: []models.NamedParamExample{{ParamName: "order_id", Example: "A1"}, {ParamName: "ship_date", Example: "today"}},
+			}},
+		}, nil},
+		{"named with numbers", "UTILITY", "NAMED", []models.TemplateComponentDef{body("Order {{1}} shipped.", "A")},
+			[]string{"components[0].text"}},
+		{"named examples", "UTILITY", "NAMED", []models.TemplateComponentDef{
+			{Type: models.TemplateDefBody, Text: "Order {{order_id}} shipped.", Example: &models.TemplateExample{
+				BodyTextNamedParams: []models.NamedParamExample{{ParamName: "order", Example: "A1"}},
+			}},
+		}, []string{"components[0].example.body_text_named_params", "components[0].example.body_text_named_params"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs errors.ValidationErrors
+			ValidateComponents(&errs, tt.category, tt.parameterFormat, tt.components)
+			if got := fields(t, errs.ErrOrNil()); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Fatalf("error fields = %q, want %q\n%v", got, tt.wantFields, errs)
+			}
+		})
+	}
+}
+
+func TestCheckPlaceholders(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		named      bool
+		wantParams []string
+		wantErrs   int
+	}{
+		{"none", "Hello", false, nil, 0},
+		{"positional", "{{1}}, {{2}} and {{1}}", false, []string{"1", "2"}, 0},
+		{"positional with spaces", "{{ 1 }}", false, []string{"1"}, 0},
+		{"positional gap", "{{1}} {{3}}", false, []string{"1", "3"}, 1},
+		{"positional zero", "{{0}}", false, nil, 1},
+		{"positional name", "{{name}}", false, nil, 1},
+		{"named", "{{first_name}} {{order_id}} {{first_name}}", true, []string{"first_name", "order_id"}, 0},
+		{"named uppercase", "{{FirstName}}", true, nil, 1},
+		{"named number", "{{1}}", true, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs errors.ValidationErrors
+			params := checkPlaceholders(&errs, "text", tt.text, tt.named)
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Fatalf("params = %q, want %q", params, tt.wantParams)
+			}
+			if len(errs) != tt.wantErrs {
+				t.Fatalf("%d errors, want %d: %v", len(errs), tt.wantErrs, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package webhook provides a common interface for webhook events and
+// subscriptions that stream them to independent consumers.
+package webhook
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// EventType identifies the kind of an event.
+type EventType string
+
+const (
+	EventText              EventType = "text"
+	EventImage             EventType = "image"
+	EventVideo             EventType = "video"
+	EventAudio             EventType = "audio"
+	EventDocument          EventType = "document"
+	EventSticker           EventType = "sticker"
+	EventLocation          EventType = "location"
+	EventContacts          EventType = "contacts"
+	EventButtonReply       EventType = "button_reply"
+	EventListReply         EventType = "list_reply"
+	EventFlowResponse      EventType = "flow_response"
+	EventAddressSubmission EventType = "address_submission"
+	EventReaction          EventType = "reaction"
+	EventOrder             EventType = "order"
+	EventSystem            EventType = "system"
+	EventMessageSent       EventType = "status_sent"
+	EventMessageDelivered  EventType = "status_delivered"
+	EventMessageRead       EventType = "status_read"
+	EventMessageFailed     EventType = "status_failed"
+	EventError             EventType = "error"
+)
+
+// DefaultEventBuffer is the channel buffer size of a subscription.
+const DefaultEventBuffer = 64
+
+// Event is implemented by every event the handler emits.
+type Event interface {
+	// Meta returns the fields common to all events.
+	Meta() EventMeta
+}
+
+// EventMeta contains the fields common to all events.
+type EventMeta struct {
+	Type      EventType
+	MessageID string // Empty for error events
+	Sender    string // wa_id of the user; the recipient for status events
+	PhoneID   string // Business phone number ID that received the event
+	Timestamp string // Unix timestamp in seconds, as sent by the API
+	Payload   *models.WebhookPayload
+}
+
+// Time returns Timestamp as a time, or the zero time if it is not set.
+func (m EventMeta) Time() time.Time {
+	seconds, err := strconv.ParseInt(m.Timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// eventMeta is embedded in event structs to implement Event.
+type eventMeta struct {
+	meta EventMeta
+}
+
+// Meta implements Event.
+func (e *eventMeta) Meta() EventMeta {
+	return e.meta
+}
+
+// ===============================
+// Filters
+// ===============================
+
+// EventFilter reports whether a subscription receives an event.
+type EventFilter func(Event) bool
+
+// OfType matches events of any of the given types.
+func OfType(types ...EventType) EventFilter {
+	return func(e Event) bool {
+		t := e.Meta().Type
+		for _, want := range types {
+			if t == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FromSender matches events from or, for status events, to any of the
+// given wa_ids.
+func FromSender(waIDs ...string) EventFilter {
+	return func(e Event) bool {
+		sender := e.Meta().Sender
+		for _, id := range waIDs {
+			if sender == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ForPhoneID matches events received by any of the given phone number IDs.
+func ForPhoneID(phoneIDs ...string) EventFilter {
+	return func(e Event) bool {
+		phoneID := e.Meta().PhoneID
+		for _, id := range phoneIDs {
+			if phoneID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MessagesOnly matches inbound message events, excluding system
+// messages, status updates and errors.
+func MessagesOnly() EventFilter {
+	return func(e Event) bool {
+		switch e.Meta().Type {
+		case EventText, EventImage, EventVideo, EventAudio, EventDocument,
+			EventSticker, EventLocation, EventContacts, EventButtonReply,
+			EventListReply, EventFlowResponse, EventAddressSubmission,
+			EventReaction, EventOrder:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// ===============================
+// Subscriptions
+// ===============================
+
+// Subscription streams events to one consumer. Events are delivered on C
+// in the order they are processed. If the consumer falls behind and the
+// buffer is full, new events are dropped rather than blocking the handler.
+type Subscription struct {
+	// C receives the events matching all of the subscription's filters
+	C <-chan Event
+
+	ch      chan Event
+	filters []EventFilter
+	handler *Handler
+	dropped atomic.Int64
+	once    sync.Once
+
+	// mu guards sends on ch against Close
+	mu     sync.Mutex
+	closed bool
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close ends the subscription and closes C.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.handler.subsMu.Lock()
+		delete(s.handler.subs, s)
+		s.handler.subsMu.Unlock()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.ch)
+	})
+}
+
+// matches reports whether e passes all filters of s.
+func (s *Subscription) matches(e Event) bool {
+	for _, filter := range s.filters {
+		if !filter(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Events subscribes to the events matching all filters; with no filters
+// every event is received. The subscription must be closed when no longer
+// needed. Any number of subscriptions may be open at once.
+func (h *Handler) Events(filters ...EventFilter) *Subscription {
+	size := h.eventBuffer
+	if size <= 0 {
+		size = DefaultEventBuffer
+	}
+
+	ch := make(chan Event, size)
+	sub := &Subscription{
+		C:       ch,
+		ch:      ch,
+		filters: filters,
+		handler: h,
+	}
+
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[*Subscription]struct{})
+	}
+	h.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// deliver sends e on the subscription's channel without blocking. It
+// reports false if the buffer was full; events arriving after Close are
+// discarded.
+func (s *Subscription) deliver(e Event) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+	select {
+	case s.ch <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// publish delivers e to every matching subscription. The filters run
+// outside subsMu, so they may open or close subscriptions.
+func (h *Handler) publish(e Event) {
+	h.subsMu.Lock()
+	subs := make([]*Subscription, 0, len(h.subs))
+	for sub := range h.subs {
+		subs = append(subs, sub)
+	}
+	h.subsMu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.matches(e) || sub.deliver(e) {
+			continue
+		}
+		if sub.dropped.Add(1) == 1 {
+			h.logger.Printf("Event subscription is full, dropping %s events", e.Meta().Type)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMessagesOnly(t *testing.T) {
+	tests := []struct {
+		typ  EventType
+		want bool
+	}{
+		{EventText, true},
+		{EventImage, true},
+		{EventVideo, true},
+		{EventAudio, true},
+		{EventDocument, true},
+		{EventSticker, true},
+		{EventLocation, true},
+		{EventContacts, true},
+		{EventButtonReply, true},
+		{EventListReply, true},
+		{EventFlowResponse, true},
+		{EventAddressSubmission, true},
+		{EventReaction, true},
+		{EventOrder, true},
+		{EventSystem, false},
+		{EventMessageSent, false},
+		{EventMessageDelivered, false},
+		{EventMessageRead, false},
+		{EventMessageFailed, false},
+		{EventError, false},
+	}
+
+	filter := MessagesOnly()
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			event := &TextMessageEvent{}
+			event.meta = EventMeta{Type: tt.typ}
+			if got := filter(event); got != tt.want {
+				t.Fatalf("MessagesOnly(%s) = %v, want %v", tt.typ, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishFiltersRunOutsideLock(t *testing.T) {
+	h := &Handler{logger: &defaultLogger{}}
+
+	// A filter that opens and closes subscriptions would deadlock if
+	// filters ran with the subscriptions locked
+	var sub *Subscription
+	sub = h.Events(func(e Event) bool {
+		h.Events().Close()
+		sub.Close()
+		return true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		h.publish(&TextMessageEvent{})
+		h.publish(&TextMessageEvent{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish deadlocked")
+	}
+	if _, ok := <-sub.C; ok {
+		t.Fatal("event delivered to a closed subscription")
+	}
+}
@@ -0,0 +1,222 @@
+// Package webhook provides encryption for the WhatsApp Flows data endpoint.
+package webhook
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// EncryptedFlowRequest is the body of a request sent to the Flows data endpoint.
+type EncryptedFlowRequest struct {
+	EncryptedFlowData string `json:"encrypted_flow_data"`
+	EncryptedAESKey   string `json:"encrypted_aes_key"`
+	InitialVector     string `json:"initial_vector"`
+}
+
+// ===============================
+// Keys
+// ===============================
+
+// ParseFlowPrivateKey parses an unencrypted PEM encoded RSA private key in
+// PKCS#1 or PKCS#8 form. Encrypted keys, such as those produced by
+// openssl genrsa -des3, are rejected: legacy PEM encryption is insecure, so
+// decrypt the key (openssl pkcs8 -topk8 -nocrypt) and protect it with a
+// secret store instead.
+func ParseFlowPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in private key")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return nil, fmt.Errorf("encrypted private keys are not supported; decrypt it with openssl pkcs8 -topk8 -nocrypt")
+	}
+
+	der := block.Bytes
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// GenerateFlowKeyPair generates an RSA key pair for the Flows data endpoint.
+// It returns the PEM encoded private key and the PEM encoded public key to
+// upload with SetFlowsEncryptionPublicKey. bits defaults to 2048.
+func GenerateFlowKeyPair(bits int) (privatePEM, publicPEM []byte, err error) {
+	if bits <= 0 {
+		bits = 2048
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+
+	return privatePEM, publicPEM, nil
+}
+
+// ===============================
+// Endpoint Side
+// ===============================
+
+// decryptFlowRequest decrypts a data endpoint request and returns the
+// plaintext along with the AES key and IV needed to encrypt the response.
+func decryptFlowRequest(key *rsa.PrivateKey, req *EncryptedFlowRequest) (plaintext, aesKey, iv []byte, err error) {
+	encryptedKey, err := base64.StdEncoding.DecodeString(req.EncryptedAESKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid encrypted_aes_key: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(req.EncryptedFlowData)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid encrypted_flow_data: %w", err)
+	}
+	iv, err = base64.StdEncoding.DecodeString(req.InitialVector)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid initial_vector: %w", err)
+	}
+
+	aesKey, err = rsa.DecryptOAEP(sha256.New(), nil, key, encryptedKey, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decrypt AES key: %w", err)
+	}
+
+	gcm, err := newFlowGCM(aesKey, len(iv))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	plaintext, err = gcm.Open(nil, iv, ciphertext, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decrypt flow data: %w", err)
+	}
+
+	return plaintext, aesKey, iv, nil
+}
+
+// encryptFlowResponse encrypts a response with the request's AES key and
+// the bitwise inverse of its IV, and returns it base64 encoded.
+func encryptFlowResponse(aesKey, iv, plaintext []byte) (string, error) {
+	gcm, err := newFlowGCM(aesKey, len(iv))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nil, flipIV(iv), plaintext, nil)), nil
+}
+
+// ===============================
+// Client Side (testing)
+// ===============================
+
+// EncryptFlowRequest encrypts a request the way WhatsApp does, so a data
+// endpoint can be exercised with a generated key pair. The returned AES key
+// and IV are passed to DecryptFlowResponse to read the endpoint's reply.
+func EncryptFlowRequest(pub *rsa.PublicKey, request interface{}) (req *EncryptedFlowRequest, aesKey, iv []byte, err error) {
+	plaintext, err := json.Marshal(request)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	aesKey = make([]byte, 16)
+	iv = make([]byte, 16)
+	if _, err := rand.Read(aesKey); err != nil {
+		return nil, nil, nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, nil, nil, err
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, aesKey, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encrypt AES key: %w", err)
+	}
+
+	gcm, err := newFlowGCM(aesKey, len(iv))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	req = &EncryptedFlowRequest{
+		EncryptedFlowData: base64.StdEncoding.EncodeToString(gcm.Seal(nil, iv, plaintext, nil)),
+		EncryptedAESKey:   base64.StdEncoding.EncodeToString(encryptedKey),
+		InitialVector:     base64.StdEncoding.EncodeToString(iv),
+	}
+
+	return req, aesKey, iv, nil
+}
+
+// DecryptFlowResponse decrypts a base64 encoded data endpoint response.
+func DecryptFlowResponse(body string, aesKey, iv []byte) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response encoding: %w", err)
+	}
+
+	gcm, err := newFlowGCM(aesKey, len(iv))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, flipIV(iv), ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt response: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// newFlowGCM creates an AES-GCM cipher. Flows use a 16 byte IV rather
+// than the standard 12 byte GCM nonce.
+func newFlowGCM(key []byte, ivSize int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AES key: %w", err)
+	}
+	if ivSize == 0 {
+		return nil, fmt.Errorf("initial vector is empty")
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, ivSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// flipIV returns the bitwise inverse of iv, used to encrypt responses.
+func flipIV(iv []byte) []byte {
+	flipped := make([]byte, len(iv))
+	for i, b := range iv {
+		flipped[i] = ^b
+	}
+	return flipped
+}
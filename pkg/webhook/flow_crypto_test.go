@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+)
+
+func TestFlowEncryptionRoundTrip(t *testing.T) {
+	privatePEM, publicPEM, err := GenerateFlowKeyPair(2048)
+	if err != nil {
+		t.Fatalf("GenerateFlowKeyPair: %v", err)
+	}
+
+	key, err := ParseFlowPrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("ParseFlowPrivateKey: %v", err)
+	}
+	block, _ := pem.Decode(publicPEM)
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey: %v", err)
+	}
+	if !key.PublicKey.Equal(parsed) {
+		t.Fatal("public key does not match private key")
+	}
+
+	request := FlowRequest{Version: "3.0", Action: FlowRequestInit, FlowToken: "token"}
+	encrypted, aesKey, iv, err := EncryptFlowRequest(&key.PublicKey, request)
+	if err != nil {
+		t.Fatalf("EncryptFlowRequest: %v", err)
+	}
+
+	plaintext, gotKey, gotIV, err := decryptFlowRequest(key, encrypted)
+	if err != nil {
+		t.Fatalf("decryptFlowRequest: %v", err)
+	}
+	if !bytes.Equal(gotKey, aesKey) || !bytes.Equal(gotIV, iv) {
+		t.Fatal("decrypted AES key or IV differs from the encrypted one")
+	}
+	var decoded FlowRequest
+	if err := json.Unmarshal(plaintext, &decoded); err != nil {
+		t.Fatalf("decoding request: %v", err)
+	}
+	if decoded.Action != request.Action || decoded.FlowToken != request.FlowToken {
+		t.Fatalf("decrypted request = %+v, want %+v", decoded, request)
+	}
+
+	response := []byte(`{"screen":"NEXT"}`)
+	body, err := encryptFlowResponse(gotKey, gotIV, response)
+	if err != nil {
+		t.Fatalf("encryptFlowResponse: %v", err)
+	}
+	got, err := DecryptFlowResponse(body, aesKey, iv)
+	if err != nil {
+		t.Fatalf("DecryptFlowResponse: %v", err)
+	}
+	if !bytes.Equal(got, response) {
+		t.Fatalf("DecryptFlowResponse = %s, want %s", got, response)
+	}
+
+	// Responses are encrypted with the inverted IV, so the request IV must not open them
+	ciphertext, _ := base64.StdEncoding.DecodeString(body)
+	gcm, err := newFlowGCM(aesKey, len(iv))
+	if err != nil {
+		t.Fatalf("newFlowGCM: %v", err)
+	}
+	if _, err := gcm.Open(nil, iv, ciphertext, nil); err == nil {
+		t.Fatal("response decrypted with the request IV; it must use the flipped IV")
+	}
+	if _, err := gcm.Open(nil, flipIV(iv), ciphertext, nil); err != nil {
+		t.Fatalf("response does not decrypt with the flipped IV: %v", err)
+	}
+}
+
+func TestFlipIV(t *testing.T) {
+	iv := []byte{0x00, 0xff, 0x0f, 0xa5}
+	want := []byte{0xff, 0x00, 0xf0, 0x5a}
+	if got := flipIV(iv); !bytes.Equal(got, want) {
+		t.Fatalf("flipIV(%x) = %x, want %x", iv, got, want)
+	}
+	if got := flipIV(flipIV(iv)); !bytes.Equal(got, iv) {
+		t.Fatalf("flipping twice = %x, want %x", got, iv)
+	}
+}
+
+func TestParseFlowPrivateKeyRejectsEncryptedKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		block *pem.Block
+	}{
+		{"legacy PEM encryption", &pem.Block{Type: "RSA PRIVATE KEY", Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "DES-EDE3-CBC,0000000000000000"}, Bytes: []byte{0}}},
+		{"encrypted PKCS#8", &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFlowPrivateKey(pem.EncodeToMemory(tt.block)); err == nil {
+				t.Fatal("expected an error for an encrypted key")
+			}
+		})
+	}
+}
+
+func TestParseFlowErrorNotification(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		data   string
+		want   bool
+	}{
+		{"error notification", FlowRequestDataExchange, `{"error":"invalid-screen","error_message":"Screen not found"}`, true},
+		{"form field named error", FlowRequestDataExchange, `{"error":"yes","name":"Ana"}`, false},
+		{"error without message", FlowRequestDataExchange, `{"error":"invalid-screen"}`, false},
+		{"non-string error", FlowRequestDataExchange, `{"error":1,"error_message":"x"}`, false},
+		{"empty error", FlowRequestDataExchange, `{"error":"","error_message":"x"}`, false},
+		{"other action", FlowRequestBack, `{"error":"invalid-screen","error_message":"Screen not found"}`, false},
+		{"no data", FlowRequestDataExchange, ``, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &FlowRequest{Action: tt.action, Screen: "FORM", Data: json.RawMessage(tt.data)}
+			got := parseFlowErrorNotification(req)
+			if (got != nil) != tt.want {
+				t.Fatalf("parseFlowErrorNotification = %+v, want notification: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+// Package webhook provides the WhatsApp Flows data endpoint.
+package webhook
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/yourusername/whatsapp-go/pkg/client"
+	"github.com/yourusername/whatsapp-go/pkg/config"
+)
+
+// Flow data endpoint request actions
+const (
+	FlowRequestPing         = "ping"
+	FlowRequestInit         = "INIT"
+	FlowRequestDataExchange = "data_exchange"
+	FlowRequestBack         = "BACK"
+)
+
+// Flow data endpoint HTTP status codes with a special meaning to WhatsApp
+const (
+	// StatusFlowDecryptFailed makes WhatsApp re-fetch the public key and retry
+	StatusFlowDecryptFailed = 421
+	// StatusFlowTokenInvalid shows the user an error and disables the flow
+	StatusFlowTokenInvalid = 427
+	// StatusFlowSignatureInvalid reports a request signature mismatch
+	StatusFlowSignatureInvalid = 432
+)
+
+// ErrInvalidFlowToken is returned by a screen handler to reject a flow token
+// that is expired or unknown.
+var ErrInvalidFlowToken = errors.New("invalid flow token")
+
+// FlowRequest is a decrypted request sent to the data endpoint.
+type FlowRequest struct {
+	Version   string          `json:"version"`
+	Action    string          `json:"action"`
+	Screen    string          `json:"screen,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	FlowToken string          `json:"flow_token,omitempty"`
+}
+
+// Decode unmarshals the request data into v.
+func (r *FlowRequest) Decode(v interface{}) error {
+	if len(r.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s data: %w", r.Screen, err)
+	}
+	return nil
+}
+
+// FlowResponse is the response returned to the flow.
+type FlowResponse struct {
+	Screen string      `json:"screen,omitempty"`
+	Data   interface{} `json:"data"`
+}
+
+// NextScreen returns a response that navigates to screen with data.
+func NextScreen(screen string, data interface{}) *FlowResponse {
+	return &FlowResponse{Screen: screen, Data: data}
+}
+
+// ScreenError returns a response that stays on screen and shows message.
+func ScreenError(screen, message string) *FlowResponse {
+	return &FlowResponse{Screen: screen, Data: map[string]interface{}{"error_message": message}}
+}
+
+// CloseFlow returns a response that completes the flow. params are sent back
+// with the flow response message along with the flow token.
+func CloseFlow(flowToken string, params map[string]interface{}) *FlowResponse {
+	merged := map[string]interface{}{"flow_token": flowToken}
+	for k, v := range params {
+		merged[k] = v
+	}
+
+	return &FlowResponse{
+		Screen: "SUCCESS",
+		Data: map[string]interface{}{
+			"extension_message_response": map[string]interface{}{
+				"params": merged,
+			},
+		},
+	}
+}
+
+// FlowErrorNotification is sent by WhatsApp when the flow failed to handle a
+// previous response of the data endpoint.
+type FlowErrorNotification struct {
+	FlowToken    string
+	Action       string
+	Screen       string
+	Error        string
+	ErrorMessage string
+}
+
+// FlowHandlerFunc handles a data endpoint request.
+type FlowHandlerFunc func(ctx context.Context, req *FlowRequest) (*FlowResponse, error)
+
+// FlowEndpoint is an http.Handler for the Flows data endpoint. It decrypts
+// requests, dispatches them to the registered handlers and encrypts the
+// responses.
+type FlowEndpoint struct {
+	privateKey  *rsa.PrivateKey
+	appSecret   string
+	validateSig bool
+	logger      Logger
+
+	mu          sync.RWMutex
+	initHandler FlowHandlerFunc
+	backHandler FlowHandlerFunc
+	fallback    FlowHandlerFunc
+	screens     map[string]FlowHandlerFunc
+	onError     func(ctx context.Context, notification *FlowErrorNotification)
+	onPing      func(ctx context.Context) error
+}
+
+// FlowEndpointOption is a function that configures the flow endpoint.
+type FlowEndpointOption func(*FlowEndpoint)
+
+// WithFlowLogger sets a custom logger.
+func WithFlowLogger(logger Logger) FlowEndpointOption {
+	return func(e *FlowEndpoint) {
+		e.logger = logger
+	}
+}
+
+// WithFlowPrivateKey sets the private key instead of loading it from config.
+func WithFlowPrivateKey(key *rsa.PrivateKey) FlowEndpointOption {
+	return func(e *FlowEndpoint) {
+		e.privateKey = key
+	}
+}
+
+// WithFlowSignatureValidation enables/disables signature validation.
+func WithFlowSignatureValidation(validate bool) FlowEndpointOption {
+	return func(e *FlowEndpoint) {
+		e.validateSig = validate
+	}
+}
+
+// NewFlowEndpoint creates a new Flows data endpoint. The private key is read
+// from cfg.FlowPrivateKey unless WithFlowPrivateKey is given. Requests are
+// signature checked when cfg.AppSecret is set.
+func NewFlowEndpoint(cfg *config.Config, opts ...FlowEndpointOption) (*FlowEndpoint, error) {
+	e := &FlowEndpoint{
+		appSecret:   cfg.AppSecret,
+		validateSig: true,
+		logger:      &defaultLogger{},
+		screens:     make(map[string]FlowHandlerFunc),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.privateKey == nil {
+		if err := cfg.ValidateForFlowEndpoint(); err != nil {
+			return nil, err
+		}
+		key, err := ParseFlowPrivateKey([]byte(cfg.FlowPrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		e.privateKey = key
+	}
+
+	return e, nil
+}
+
+// ===============================
+// Handler Registration
+// ===============================
+
+// HandleInit sets the handler for INIT requests, sent when a flow using the
+// data_exchange action is opened.
+func (e *FlowEndpoint) HandleInit(fn FlowHandlerFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.initHandler = fn
+}
+
+// HandleBack sets the handler for BACK requests, sent when the user goes back
+// to a screen with refresh_on_back enabled. Without it, BACK requests go to
+// the handler of the request's screen.
+func (e *FlowEndpoint) HandleBack(fn FlowHandlerFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.backHandler = fn
+}
+
+// HandleScreen sets the handler for data_exchange requests from screen.
+func (e *FlowEndpoint) HandleScreen(screen string, fn FlowHandlerFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.screens[screen] = fn
+}
+
+// HandleDefault sets the handler for requests without a specific handler.
+func (e *FlowEndpoint) HandleDefault(fn FlowHandlerFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fallback = fn
+}
+
+// OnErrorNotification sets the handler for error notifications.
+func (e *FlowEndpoint) OnErrorNotification(fn func(ctx context.Context, notification *FlowErrorNotification)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onError = fn
+}
+
+// OnHealthCheck sets a check run for ping requests. Returning an error
+// reports the endpoint as unavailable.
+func (e *FlowEndpoint) OnHealthCheck(fn func(ctx context.Context) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onPing = fn
+}
+
+// HandleScreenData registers a handler for screen that receives the request
+// data decoded into T.
+//
+//	webhook.HandleScreenData(endpoint, "APPOINTMENT", func(ctx context.Context, req *webhook.FlowRequest, data AppointmentForm) (*webhook.FlowResponse, error) {
+//	    return webhook.NextScreen("SUMMARY", map[string]interface{}{"date": data.Date}), nil
+//	})
+func HandleScreenData[T any](e *FlowEndpoint, screen string, fn func(ctx context.Context, req *FlowRequest, data T) (*FlowResponse, error)) {
+	e.HandleScreen(screen, func(ctx context.Context, req *FlowRequest) (*FlowResponse, error) {
+		var data T
+		if err := req.Decode(&data); err != nil {
+			return nil, err
+		}
+		return fn(ctx, req, data)
+	})
+}
+
+// ===============================
+// HTTP Handler
+// ===============================
+
+// ServeHTTP implements the http.Handler interface.
+func (e *FlowEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.logger.Printf("Error reading flow request body: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if e.validateSig && e.appSecret != "" {
+		if !client.VerifySignature(e.appSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+			e.logger.Printf("Invalid flow request signature")
+			http.Error(w, "Invalid signature", StatusFlowSignatureInvalid)
+			return
+		}
+	}
+
+	var encrypted EncryptedFlowRequest
+	if err := json.Unmarshal(body, &encrypted); err != nil {
+		e.logger.Printf("Error parsing flow request: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	plaintext, aesKey, iv, err := decryptFlowRequest(e.privateKey, &encrypted)
+	if err != nil {
+		e.logger.Printf("Error decrypting flow request: %v", err)
+		http.Error(w, "Decryption failed", StatusFlowDecryptFailed)
+		return
+	}
+
+	var req FlowRequest
+	if err := json.Unmarshal(plaintext, &req); err != nil {
+		e.logger.Printf("Error parsing decrypted flow request: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := e.dispatch(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidFlowToken) {
+			http.Error(w, "Invalid flow token", StatusFlowTokenInvalid)
+			return
+		}
+		e.logger.Printf("Error handling flow %s request: %v", req.Action, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if resp.Data == nil {
+		resp.Data = map[string]interface{}{}
+	}
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		e.logger.Printf("Error encoding flow response: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	encoded, err := encryptFlowResponse(aesKey, iv, respJSON)
+	if err != nil {
+		e.logger.Printf("Error encrypting flow response: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(encoded))
+}
+
+// dispatch routes a decrypted request to its handler.
+func (e *FlowEndpoint) dispatch(ctx context.Context, req *FlowRequest) (*FlowResponse, error) {
+	e.mu.RLock()
+	initHandler, backHandler, fallback := e.initHandler, e.backHandler, e.fallback
+	screenHandler := e.screens[req.Screen]
+	onError, onPing := e.onError, e.onPing
+	e.mu.RUnlock()
+
+	if req.Action == FlowRequestPing {
+		if onPing != nil {
+			if err := onPing(ctx); err != nil {
+				return nil, fmt.Errorf("health check failed: %w", err)
+			}
+		}
+		return &FlowResponse{Data: map[string]interface{}{"status": "active"}}, nil
+	}
+
+	if notification := parseFlowErrorNotification(req); notification != nil {
+		if onError != nil {
+			onError(ctx, notification)
+		} else {
+			e.logger.Printf("Flow error notification for screen %s: %s (%s)", notification.Screen, notification.Error, notification.ErrorMessage)
+		}
+		return &FlowResponse{Data: map[string]interface{}{"acknowledged": true}}, nil
+	}
+
+	var handler FlowHandlerFunc
+	switch req.Action {
+	case FlowRequestInit:
+		handler = initHandler
+	case FlowRequestBack:
+		handler = backHandler
+		if handler == nil {
+			handler = screenHandler
+		}
+	case FlowRequestDataExchange:
+		handler = screenHandler
+	default:
+		return nil, fmt.Errorf("unknown flow action %q", req.Action)
+	}
+	if handler == nil {
+		handler = fallback
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("no handler registered for %s on screen %q", req.Action, req.Screen)
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("handler for %s on screen %q returned no response", req.Action, req.Screen)
+	}
+
+	return resp, nil
+}
+
+// ===============================
+// Helper Functions
+// ===============================
+
+// parseFlowErrorNotification returns the error notification carried by req,
+// or nil if req is a regular request. Notifications are data_exchange
+// requests whose data consists of exactly the string fields error and
+// error_message, so a screen submitting a field named "error" is not
+// mistaken for one.
+func parseFlowErrorNotification(req *FlowRequest) *FlowErrorNotification {
+	if req.Action != FlowRequestDataExchange || len(req.Data) == 0 {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(req.Data, &fields); err != nil || len(fields) != 2 {
+		return nil
+	}
+
+	var data struct {
+		Error        string
+		ErrorMessage string
+	}
+	if json.Unmarshal(fields["error"], &data.Error) != nil || json.Unmarshal(fields["error_message"], &data.ErrorMessage) != nil {
+		return nil
+	}
+	if data.Error == "" {
+		return nil
+	}
+
+	return &FlowErrorNotification{
+		FlowToken:    req.FlowToken,
+		Action:       req.Action,
+		Screen:       req.Screen,
+		Error:        data.Error,
+		ErrorMessage: data.ErrorMessage,
+	}
+}
@@ -1,553 +1,947 @@
-// Package webhook provides webhook handling for the WhatsApp API.
-package webhook
-
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"sync"
-
-	"github.com/yourusername/whatsapp-go/pkg/client"
-	"github.com/yourusername/whatsapp-go/pkg/config"
-	"github.com/yourusername/whatsapp-go/pkg/models"
-)
-
-// Handler is the webhook handler.
-type Handler struct {
-	config        *config.Config
-	client        *client.Client
-	handlers      *EventHandlers
-	mu            sync.RWMutex
-	logger        Logger
-	verifyToken   string
-	validateSig   bool
-}
-
-// Logger interface for custom logging.
-type Logger interface {
-	Printf(format string, v ...interface{})
-}
-
-// defaultLogger is a simple logger using the standard log package.
-type defaultLogger struct{}
-
-func (l *defaultLogger) Printf(format string, v ...interface{}) {
-	log.Printf(format, v...)
-}
-
-// EventHandlers contains all event handler functions.
-type EventHandlers struct {
-	// Message handlers
-	OnTextMessage        func(ctx context.Context, msg *TextMessageEvent)
-	OnImageMessage       func(ctx context.Context, msg *MediaMessageEvent)
-	OnVideoMessage       func(ctx context.Context, msg *MediaMessageEvent)
-	OnAudioMessage       func(ctx context.Context, msg *MediaMessageEvent)
-	OnDocumentMessage    func(ctx context.Context, msg *DocumentMessageEvent)
-	OnStickerMessage     func(ctx context.Context, msg *MediaMessageEvent)
-	OnLocationMessage    func(ctx context.Context, msg *LocationMessageEvent)
-	OnContactsMessage    func(ctx context.Context, msg *ContactsMessageEvent)
-	OnButtonReply        func(ctx context.Context, msg *ButtonReplyEvent)
-	OnListReply          func(ctx context.Context, msg *ListReplyEvent)
-	OnReactionMessage    func(ctx context.Context, msg *ReactionMessageEvent)
-
-	// Status handlers
-	OnMessageSent        func(ctx context.Context, status *MessageStatusEvent)
-	OnMessageDelivered   func(ctx context.Context, status *MessageStatusEvent)
-	OnMessageRead        func(ctx context.Context, status *MessageStatusEvent)
-	OnMessageFailed      func(ctx context.Context, status *MessageStatusEvent)
-
-	// Error handler
-	OnError              func(ctx context.Context, err *WebhookErrorEvent)
-
-	// Raw handler (receives all events)
-	OnRawWebhook         func(ctx context.Context, payload *models.WebhookPayload)
-}
-
-// Option is a function that configures the handler.
-type Option func(*Handler)
-
-// WithLogger sets a custom logger.
-func WithLogger(logger Logger) Option {
-	return func(h *Handler) {
-		h.logger = logger
-	}
-}
-
-// WithSignatureValidation enables/disables signature validation.
-func WithSignatureValidation(validate bool) Option {
-	return func(h *Handler) {
-		h.validateSig = validate
-	}
-}
-
-// NewHandler creates a new webhook handler.
-func NewHandler(cfg *config.Config, waClient *client.Client, opts ...Option) (*Handler, error) {
-	if err := cfg.ValidateForWebhook(); err != nil {
-		return nil, err
-	}
-
-	h := &Handler{
-		config:      cfg,
-		client:      waClient,
-		handlers:    &EventHandlers{},
-		logger:      &defaultLogger{},
-		verifyToken: cfg.WebhookVerifyToken,
-		validateSig: true,
-	}
-
-	for _, opt := range opts {
-		opt(h)
-	}
-
-	return h, nil
-}
-
-// SetHandlers sets the event handlers.
-func (h *Handler) SetHandlers(handlers *EventHandlers) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	h.handlers = handlers
-}
-
-// Client returns the WhatsApp client for sending replies.
-func (h *Handler) Client() *client.Client {
-	return h.client
-}
-
-// ===============================
-// HTTP Handler
-// ===============================
-
-// ServeHTTP implements the http.Handler interface.
-func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.handleVerification(w, r)
-	case http.MethodPost:
-		h.handleWebhook(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
-// handleVerification handles the webhook verification request from Meta.
-func (h *Handler) handleVerification(w http.ResponseWriter, r *http.Request) {
-	mode := r.URL.Query().Get("hub.mode")
-	token := r.URL.Query().Get("hub.verify_token")
-	challenge := r.URL.Query().Get("hub.challenge")
-
-	if mode == "subscribe" && token == h.verifyToken {
-		h.logger.Printf("Webhook verified successfully")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(challenge))
-		return
-	}
-
-	h.logger.Printf("Webhook verification failed: invalid token")
-	http.Error(w, "Forbidden", http.StatusForbidden)
-}
-
-// handleWebhook handles incoming webhook events.
-func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request) {
-	// Read body
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Printf("Error reading webhook body: %v", err)
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
-
-	// Validate signature if enabled
-	if h.validateSig && h.config.AppSecret != "" {
-		signature := r.Header.Get("X-Hub-Signature-256")
-		if !h.client.VerifyWebhookSignature(body, signature) {
-			h.logger.Printf("Invalid webhook signature")
-			http.Error(w, "Forbidden", http.StatusForbidden)
-			return
-		}
-	}
-
-	// Parse payload
-	var payload models.WebhookPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
-		h.logger.Printf("Error parsing webhook payload: %v", err)
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
-
-	// Acknowledge receipt immediately
-	w.WriteHeader(http.StatusOK)
-
-	// Process events asynchronously
-	go h.processPayload(r.Context(), &payload)
-}
-
-// ===============================
-// Event Processing
-// ===============================
-
-// processPayload processes the webhook payload and dispatches events.
-func (h *Handler) processPayload(ctx context.Context, payload *models.WebhookPayload) {
-	h.mu.RLock()
-	handlers := h.handlers
-	h.mu.RUnlock()
-
-	// Call raw handler if set
-	if handlers.OnRawWebhook != nil {
-		handlers.OnRawWebhook(ctx, payload)
-	}
-
-	// Process each entry
-	for _, entry := range payload.Entry {
-		for _, change := range entry.Changes {
-			if change.Field != "messages" {
-				continue
-			}
-
-			value := change.Value
-
-			// Process messages
-			for _, msg := range value.Messages {
-				h.processMessage(ctx, handlers, &msg, &value)
-			}
-
-			// Process statuses
-			for _, status := range value.Statuses {
-				h.processStatus(ctx, handlers, &status, &value)
-			}
-
-			// Process errors
-			for _, err := range value.Errors {
-				if handlers.OnError != nil {
-					handlers.OnError(ctx, &WebhookErrorEvent{
-						Error:    err,
-						Metadata: value.Metadata,
-					})
-				}
-			}
-		}
-	}
-}
-
-// processMessage processes a single incoming message.
-func (h *Handler) processMessage(ctx context.Context, handlers *EventHandlers, msg *models.IncomingMessage, value *models.WebhookValue) {
-	// Find contact info
-	var contact *models.WebhookContact
-	for i := range value.Contacts {
-		if value.Contacts[i].WaID == msg.From {
-			contact = &value.Contacts[i]
-			break
-		}
-	}
-
-	baseEvent := BaseMessageEvent{
-		MessageID:   msg.ID,
-		From:        msg.From,
-		Timestamp:   msg.Timestamp,
-		PhoneNumber: value.Metadata.DisplayPhoneNumber,
-		PhoneID:     value.Metadata.PhoneNumberID,
-		Context:     msg.Context,
-	}
-
-	if contact != nil {
-		baseEvent.ContactName = contact.Profile.Name
-	}
-
-	switch msg.Type {
-	case models.MessageTypeText:
-		if handlers.OnTextMessage != nil && msg.Text != nil {
-			handlers.OnTextMessage(ctx, &TextMessageEvent{
-				BaseMessageEvent: baseEvent,
-				Body:             msg.Text.Body,
-			})
-		}
-
-	case models.MessageTypeImage:
-		if handlers.OnImageMessage != nil && msg.Image != nil {
-			handlers.OnImageMessage(ctx, &MediaMessageEvent{
-				BaseMessageEvent: baseEvent,
-				MediaID:          msg.Image.ID,
-				MimeType:         msg.Image.MimeType,
-				SHA256:           msg.Image.SHA256,
-				Caption:          msg.Image.Caption,
-			})
-		}
-
-	case models.MessageTypeVideo:
-		if handlers.OnVideoMessage != nil && msg.Video != nil {
-			handlers.OnVideoMessage(ctx, &MediaMessageEvent{
-				BaseMessageEvent: baseEvent,
-				MediaID:          msg.Video.ID,
-				MimeType:         msg.Video.MimeType,
-				SHA256:           msg.Video.SHA256,
-				Caption:          msg.Video.Caption,
-			})
-		}
-
-	case models.MessageTypeAudio:
-		if handlers.OnAudioMessage != nil && msg.Audio != nil {
-			handlers.OnAudioMessage(ctx, &MediaMessageEvent{
-				BaseMessageEvent: baseEvent,
-				MediaID:          msg.Audio.ID,
-				MimeType:         msg.Audio.MimeType,
-				SHA256:           msg.Audio.SHA256,
-			})
-		}
-
-	case models.MessageTypeDocument:
-		if handlers.OnDocumentMessage != nil && msg.Document != nil {
-			handlers.OnDocumentMessage(ctx, &DocumentMessageEvent{
-				BaseMessageEvent: baseEvent,
-				MediaID:          msg.Document.ID,
-				MimeType:         msg.Document.MimeType,
-				SHA256:           msg.Document.SHA256,
-				Filename:         msg.Document.Filename,
-				Caption:          msg.Document.Caption,
-			})
-		}
-
-	case models.MessageTypeSticker:
-		if handlers.OnStickerMessage != nil && msg.Sticker != nil {
-			handlers.OnStickerMessage(ctx, &MediaMessageEvent{
-				BaseMessageEvent: baseEvent,
-				MediaID:          msg.Sticker.ID,
-				MimeType:         msg.Sticker.MimeType,
-				SHA256:           msg.Sticker.SHA256,
-			})
-		}
-
-	case models.MessageTypeLocation:
-		if handlers.OnLocationMessage != nil && msg.Location != nil {
-			handlers.OnLocationMessage(ctx, &LocationMessageEvent{
-				BaseMessageEvent: baseEvent,
-				Latitude:         msg.Location.Latitude,
-				Longitude:        msg.Location.Longitude,
-				Name:             msg.Location.Name,
-				Address:          msg.Location.Address,
-			})
-		}
-
-	case models.MessageTypeContacts:
-		if handlers.OnContactsMessage != nil && len(msg.Contacts) > 0 {
-			handlers.OnContactsMessage(ctx, &ContactsMessageEvent{
-				BaseMessageEvent: baseEvent,
-				Contacts:         msg.Contacts,
-			})
-		}
-
-	case models.MessageTypeInteractive:
-		if msg.Interactive != nil {
-			switch msg.Interactive.Type {
-			case "button_reply":
-				if handlers.OnButtonReply != nil && msg.Interactive.ButtonReply != nil {
-					handlers.OnButtonReply(ctx, &ButtonReplyEvent{
-						BaseMessageEvent: baseEvent,
-						ButtonID:         msg.Interactive.ButtonReply.ID,
-						ButtonTitle:      msg.Interactive.ButtonReply.Title,
-					})
-				}
-			case "list_reply":
-				if handlers.OnListReply != nil && msg.Interactive.ListReply != nil {
-					handlers.OnListReply(ctx, &ListReplyEvent{
-						BaseMessageEvent: baseEvent,
-						RowID:            msg.Interactive.ListReply.ID,
-						RowTitle:         msg.Interactive.ListReply.Title,
-						RowDescription:   msg.Interactive.ListReply.Description,
-					})
-				}
-			}
-		}
-
-	case models.MessageTypeReaction:
-		if handlers.OnReactionMessage != nil && msg.Reaction != nil {
-			handlers.OnReactionMessage(ctx, &ReactionMessageEvent{
-				BaseMessageEvent: baseEvent,
-				ReactedMessageID: msg.Reaction.MessageID,
-				Emoji:            msg.Reaction.Emoji,
-			})
-		}
-	}
-}
-
-// processStatus processes a message status update.
-func (h *Handler) processStatus(ctx context.Context, handlers *EventHandlers, status *models.MessageStatusUpdate, value *models.WebhookValue) {
-	event := &MessageStatusEvent{
-		MessageID:   status.ID,
-		Status:      status.Status,
-		Timestamp:   status.Timestamp,
-		RecipientID: status.RecipientID,
-		PhoneNumber: value.Metadata.DisplayPhoneNumber,
-		PhoneID:     value.Metadata.PhoneNumberID,
-	}
-
-	if status.Conversation != nil {
-		event.ConversationID = status.Conversation.ID
-		event.ConversationType = status.Conversation.Origin.Type
-	}
-
-	if status.Pricing != nil {
-		event.Billable = status.Pricing.Billable
-		event.PricingCategory = status.Pricing.Category
-	}
-
-	switch status.Status {
-	case models.StatusSent:
-		if handlers.OnMessageSent != nil {
-			handlers.OnMessageSent(ctx, event)
-		}
-	case models.StatusDelivered:
-		if handlers.OnMessageDelivered != nil {
-			handlers.OnMessageDelivered(ctx, event)
-		}
-	case models.StatusRead:
-		if handlers.OnMessageRead != nil {
-			handlers.OnMessageRead(ctx, event)
-		}
-	case models.StatusFailed:
-		if handlers.OnMessageFailed != nil {
-			event.Errors = status.Errors
-			handlers.OnMessageFailed(ctx, event)
-		}
-	}
-}
-
-// ===============================
-// Event Types
-// ===============================
-
-// BaseMessageEvent contains common fields for all message events.
-type BaseMessageEvent struct {
-	MessageID   string
-	From        string
-	ContactName string
-	Timestamp   string
-	PhoneNumber string
-	PhoneID     string
-	Context     *models.MessageContext
-}
-
-// IsReply returns true if this message is a reply to another message.
-func (e *BaseMessageEvent) IsReply() bool {
-	return e.Context != nil && e.Context.ID != ""
-}
-
-// TextMessageEvent is emitted when a text message is received.
-type TextMessageEvent struct {
-	BaseMessageEvent
-	Body string
-}
-
-// MediaMessageEvent is emitted when a media message is received.
-type MediaMessageEvent struct {
-	BaseMessageEvent
-	MediaID  string
-	MimeType string
-	SHA256   string
-	Caption  string
-}
-
-// DocumentMessageEvent is emitted when a document message is received.
-type DocumentMessageEvent struct {
-	BaseMessageEvent
-	MediaID  string
-	MimeType string
-	SHA256   string
-	Filename string
-	Caption  string
-}
-
-// LocationMessageEvent is emitted when a location message is received.
-type LocationMessageEvent struct {
-	BaseMessageEvent
-	Latitude  float64
-	Longitude float64
-	Name      string
-	Address   string
-}
-
-// ContactsMessageEvent is emitted when a contacts message is received.
-type ContactsMessageEvent struct {
-	BaseMessageEvent
-	Contacts []models.ContactContent
-}
-
-// ButtonReplyEvent is emitted when a button reply is received.
-type ButtonReplyEvent struct {
-	BaseMessageEvent
-	ButtonID    string
-	ButtonTitle string
-}
-
-// ListReplyEvent is emitted when a list reply is received.
-type ListReplyEvent struct {
-	BaseMessageEvent
-	RowID          string
-	RowTitle       string
-	RowDescription string
-}
-
-// ReactionMessageEvent is emitted when a reaction is received.
-type ReactionMessageEvent struct {
-	BaseMessageEvent
-	ReactedMessageID string
-	Emoji            string
-}
-
-// MessageStatusEvent is emitted when a message status update is received.
-type MessageStatusEvent struct {
-	MessageID        string
-	Status           models.MessageStatus
-	Timestamp        string
-	RecipientID      string
-	PhoneNumber      string
-	PhoneID          string
-	ConversationID   string
-	ConversationType string
-	Billable         bool
-	PricingCategory  string
-	Errors           []models.WebhookError
-}
-
-// WebhookErrorEvent is emitted when a webhook error is received.
-type WebhookErrorEvent struct {
-	Error    models.WebhookError
-	Metadata models.WebhookMetadata
-}
-
-// ===============================
-// Server
-// ===============================
-
-// Server wraps the webhook handler with an HTTP server.
-type Server struct {
-	handler *Handler
-	server  *http.Server
-}
-
-// NewServer creates a new webhook server.
-func NewServer(handler *Handler, addr string) *Server {
-	mux := http.NewServeMux()
-	mux.Handle("/webhook", handler)
-
-	return &Server{
-		handler: handler,
-		server: &http.Server{
-			Addr:    addr,
-			Handler: mux,
-		},
-	}
-}
-
-// Start starts the webhook server.
-func (s *Server) Start() error {
-	s.handler.logger.Printf("Starting webhook server on %s", s.server.Addr)
-	return s.server.ListenAndServe()
-}
-
-// Shutdown gracefully shuts down the server.
-func (s *Server) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
-}
+// Package webhook provides webhook handling for the WhatsApp API.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/client"
+	"github.com/yourusername/whatsapp-go/pkg/config"
+	"github.com/yourusername/whatsapp-go/pkg/contacts"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// Handler is the webhook handler.
+type Handler struct {
+	config      *config.Config
+	client      *client.Client
+	handlers    *EventHandlers
+	mu          sync.RWMutex
+	logger      Logger
+	verifyToken string
+	validateSig bool
+	receipts    *ReceiptOptions
+	contacts    *contacts.Directory
+	middleware  []Middleware
+	recorder    RecordStore
+
+	// subs are the open event subscriptions
+	subs        map[*Subscription]struct{}
+	subsMu      sync.Mutex
+	eventBuffer int
+}
+
+// Logger interface for custom logging.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// defaultLogger is a simple logger using the standard log package.
+type defaultLogger struct{}
+
+func (l *defaultLogger) Printf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+}
+
+// EventHandlers contains all event handler functions.
+type EventHandlers struct {
+	// Message handlers
+	OnTextMessage       func(ctx context.Context, msg *TextMessageEvent)
+	OnImageMessage      func(ctx context.Context, msg *MediaMessageEvent)
+	OnVideoMessage      func(ctx context.Context, msg *MediaMessageEvent)
+	OnAudioMessage      func(ctx context.Context, msg *MediaMessageEvent)
+	OnDocumentMessage   func(ctx context.Context, msg *DocumentMessageEvent)
+	OnStickerMessage    func(ctx context.Context, msg *MediaMessageEvent)
+	OnLocationMessage   func(ctx context.Context, msg *LocationMessageEvent)
+	OnLocationReply     func(ctx context.Context, msg *LocationMessageEvent) // Location sent in reply to a message, e.g. a location request
+	OnContactsMessage   func(ctx context.Context, msg *ContactsMessageEvent)
+	OnButtonReply       func(ctx context.Context, msg *ButtonReplyEvent)
+	OnListReply         func(ctx context.Context, msg *ListReplyEvent)
+	OnFlowResponse      func(ctx context.Context, msg *FlowResponseEvent)
+	OnAddressSubmission func(ctx context.Context, msg *AddressSubmissionEvent)
+	OnReactionMessage   func(ctx context.Context, msg *ReactionMessageEvent)
+	OnOrder             func(ctx context.Context, msg *OrderEvent)
+	OnSystemMessage     func(ctx context.Context, msg *SystemMessageEvent)
+
+	// Status handlers
+	OnMessageSent      func(ctx context.Context, status *MessageStatusEvent)
+	OnMessageDelivered func(ctx context.Context, status *MessageStatusEvent)
+	OnMessageRead      func(ctx context.Context, status *MessageStatusEvent)
+	OnMessageFailed    func(ctx context.Context, status *MessageStatusEvent)
+
+	// Error handler
+	OnError func(ctx context.Context, err *WebhookErrorEvent)
+
+	// Catch-all handler, called for every event after its typed handler
+	OnEvent func(ctx context.Context, event Event)
+
+	// Raw handler (receives all events)
+	OnRawWebhook func(ctx context.Context, payload *models.WebhookPayload)
+}
+
+// Option is a function that configures the handler.
+type Option func(*Handler)
+
+// WithLogger sets a custom logger.
+func WithLogger(logger Logger) Option {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// WithSignatureValidation enables/disables signature validation.
+func WithSignatureValidation(validate bool) Option {
+	return func(h *Handler) {
+		h.validateSig = validate
+	}
+}
+
+// ReceiptOptions configures automatic read receipts for inbound messages.
+type ReceiptOptions struct {
+	// MarkRead marks each inbound message as read before its handler runs.
+	MarkRead bool
+	// Typing shows a typing indicator while the handler runs. It also
+	// marks the message as read.
+	Typing bool
+	// MinTypingDuration delays the first reply sent with the handler's
+	// context, not the handler itself, so the typing indicator is
+	// visible for at least this long before a reply can be sent.
+	MinTypingDuration time.Duration
+}
+
+// WithReceipts marks inbound messages as read and/or shows a typing
+// indicator while their handler runs. Only messages that reach a handler
+// for their type, OnEvent or an open subscription get a receipt; system
+// messages and unsupported message types never do. Failures are logged and
+// do not prevent the handler from running.
+func WithReceipts(opts ReceiptOptions) Option {
+	return func(h *Handler) {
+		h.receipts = &opts
+	}
+}
+
+// WithContactDirectory records profile names and number changes from every
+// webhook in dir before the event handlers run.
+func WithContactDirectory(dir *contacts.Directory) Option {
+	return func(h *Handler) {
+		h.contacts = dir
+	}
+}
+
+// WithEventBuffer sets the channel buffer size of event subscriptions.
+func WithEventBuffer(size int) Option {
+	return func(h *Handler) {
+		h.eventBuffer = size
+	}
+}
+
+// NewHandler creates a new webhook handler.
+func NewHandler(cfg *config.Config, waClient *client.Client, opts ...Option) (*Handler, error) {
+	if err := cfg.ValidateForWebhook(); err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		config:      cfg,
+		client:      waClient,
+		handlers:    &EventHandlers{},
+		logger:      &defaultLogger{},
+		verifyToken: cfg.WebhookVerifyToken,
+		validateSig: true,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// SetHandlers sets the event handlers.
+func (h *Handler) SetHandlers(handlers *EventHandlers) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers = handlers
+}
+
+// Client returns the WhatsApp client for sending replies.
+func (h *Handler) Client() *client.Client {
+	return h.client
+}
+
+// ===============================
+// HTTP Handler
+// ===============================
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleVerification(w, r)
+	case http.MethodPost:
+		h.handleWebhook(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleVerification handles the webhook verification request from Meta.
+func (h *Handler) handleVerification(w http.ResponseWriter, r *http.Request) {
+	mode := r.URL.Query().Get("hub.mode")
+	token := r.URL.Query().Get("hub.verify_token")
+	challenge := r.URL.Query().Get("hub.challenge")
+
+	if mode == "subscribe" && token == h.verifyToken {
+		h.logger.Printf("Webhook verified successfully")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(challenge))
+		return
+	}
+
+	h.logger.Printf("Webhook verification failed: invalid token")
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+// handleWebhook handles incoming webhook events.
+func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	receivedAt := time.Now()
+
+	// Read body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Printf("Error reading webhook body: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	// Validate signature if enabled
+	if h.validateSig && h.config.AppSecret != "" {
+		signature := r.Header.Get("X-Hub-Signature-256")
+		if !h.client.VerifyWebhookSignature(body, signature) {
+			h.logger.Printf("Invalid webhook signature")
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
+	h.record(r.Context(), r, body, receivedAt)
+
+	// Parse payload
+	var payload models.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.logger.Printf("Error parsing webhook payload: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	// Acknowledge receipt immediately
+	w.WriteHeader(http.StatusOK)
+
+	// Process events asynchronously. The request context is canceled once
+	// the response is written, so only its values are kept.
+	go h.processPayload(context.WithoutCancel(r.Context()), &payload)
+}
+
+// ===============================
+// Event Processing
+// ===============================
+
+// processPayload processes the webhook payload and dispatches events. A
+// panic anywhere in processing, including OnRawWebhook, middleware and
+// subscription filters, stops processing of the payload and is reported to
+// OnError; the Recover middleware recovers per event instead.
+func (h *Handler) processPayload(ctx context.Context, payload *models.WebhookPayload) {
+	h.mu.RLock()
+	handlers := h.handlers
+	middleware := h.middleware
+	h.mu.RUnlock()
+
+	defer h.recoverPayload(ctx, handlers, payload)
+
+	dispatch := chain(func(ctx context.Context, event Event) {
+		h.dispatch(ctx, handlers, event)
+	}, middleware)
+	emit := func(ctx context.Context, event Event) {
+		dispatch(ctx, event)
+		h.publish(event)
+	}
+
+	// Replayed webhooks were seen by both when they were received
+	if !IsReplay(ctx) {
+		if h.contacts != nil {
+			h.contacts.RecordWebhook(payload)
+		}
+		if handlers.OnRawWebhook != nil {
+			handlers.OnRawWebhook(ctx, payload)
+		}
+	}
+
+	// Process each entry
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != "messages" {
+				continue
+			}
+
+			value := change.Value
+			meta := EventMeta{
+				PhoneID: value.Metadata.PhoneNumberID,
+				Payload: payload,
+			}
+
+			// Process messages
+			for _, msg := range value.Messages {
+				event := h.processMessage(&msg, &value, meta)
+				if event == nil {
+					continue
+				}
+				// Only messages something will act on get a receipt
+				msgCtx := ctx
+				if event.Meta().Type != EventSystem && h.handled(handlers, event) {
+					msgCtx = h.sendReceipt(ctx, msg.ID)
+				}
+				emit(msgCtx, event)
+			}
+
+			// Process statuses
+			for _, status := range value.Statuses {
+				if event := processStatus(&status, &value, meta); event != nil {
+					emit(ctx, event)
+				}
+			}
+
+			// Process errors
+			for _, err := range value.Errors {
+				event := &WebhookErrorEvent{
+					Error:    err,
+					Metadata: value.Metadata,
+				}
+				event.meta = meta
+				event.meta.Type = EventError
+				emit(ctx, event)
+			}
+		}
+	}
+}
+
+// recoverPayload recovers from a panic while processing payload and
+// reports it to OnError. It must be deferred directly.
+func (h *Handler) recoverPayload(ctx context.Context, handlers *EventHandlers, payload *models.WebhookPayload) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	stack := debug.Stack()
+	h.logger.Printf("Panic processing webhook: %v\n%s", r, stack)
+
+	report := &WebhookErrorEvent{
+		Error: models.WebhookError{
+			Title:   "Webhook processing panic",
+			Message: fmt.Sprintf("panic processing webhook: %v", r),
+		},
+		Panic: r,
+		Stack: stack,
+	}
+	report.meta = EventMeta{Type: EventError, Payload: payload}
+
+	// A panic while reporting, e.g. in OnError itself, is dropped
+	defer func() { _ = recover() }()
+	h.dispatch(ctx, handlers, report)
+}
+
+// processMessage converts an incoming message into its event, or returns
+// nil if the message type is not supported.
+func (h *Handler) processMessage(msg *models.IncomingMessage, value *models.WebhookValue, meta EventMeta) Event {
+	// Find contact info
+	var contact *models.WebhookContact
+	for i := range value.Contacts {
+		if value.Contacts[i].WaID == msg.From {
+			contact = &value.Contacts[i]
+			break
+		}
+	}
+
+	baseEvent := BaseMessageEvent{
+		MessageID:   msg.ID,
+		From:        msg.From,
+		Timestamp:   msg.Timestamp,
+		PhoneNumber: value.Metadata.DisplayPhoneNumber,
+		PhoneID:     value.Metadata.PhoneNumberID,
+		Context:     msg.Context,
+	}
+
+	if contact != nil {
+		baseEvent.ContactName = contact.Profile.Name
+	}
+
+	baseEvent.meta = meta
+	baseEvent.meta.MessageID = msg.ID
+	baseEvent.meta.Sender = msg.From
+	baseEvent.meta.Timestamp = msg.Timestamp
+	typed := func(t EventType) BaseMessageEvent {
+		base := baseEvent
+		base.meta.Type = t
+		return base
+	}
+
+	switch msg.Type {
+	case models.MessageTypeText:
+		if msg.Text != nil {
+			return &TextMessageEvent{
+				BaseMessageEvent: typed(EventText),
+				Body:             msg.Text.Body,
+			}
+		}
+
+	case models.MessageTypeImage:
+		if msg.Image != nil {
+			return &MediaMessageEvent{
+				BaseMessageEvent: typed(EventImage),
+				MediaID:          msg.Image.ID,
+				MimeType:         msg.Image.MimeType,
+				SHA256:           msg.Image.SHA256,
+				Caption:          msg.Image.Caption,
+			}
+		}
+
+	case models.MessageTypeVideo:
+		if msg.Video != nil {
+			return &MediaMessageEvent{
+				BaseMessageEvent: typed(EventVideo),
+				MediaID:          msg.Video.ID,
+				MimeType:         msg.Video.MimeType,
+				SHA256:           msg.Video.SHA256,
+				Caption:          msg.Video.Caption,
+			}
+		}
+
+	case models.MessageTypeAudio:
+		if msg.Audio != nil {
+			return &MediaMessageEvent{
+				BaseMessageEvent: typed(EventAudio),
+				MediaID:          msg.Audio.ID,
+				MimeType:         msg.Audio.MimeType,
+				SHA256:           msg.Audio.SHA256,
+			}
+		}
+
+	case models.MessageTypeDocument:
+		if msg.Document != nil {
+			return &DocumentMessageEvent{
+				BaseMessageEvent: typed(EventDocument),
+				MediaID:          msg.Document.ID,
+				MimeType:         msg.Document.MimeType,
+				SHA256:           msg.Document.SHA256,
+				Filename:         msg.Document.Filename,
+				Caption:          msg.Document.Caption,
+			}
+		}
+
+	case models.MessageTypeSticker:
+		if msg.Sticker != nil {
+			return &MediaMessageEvent{
+				BaseMessageEvent: typed(EventSticker),
+				MediaID:          msg.Sticker.ID,
+				MimeType:         msg.Sticker.MimeType,
+				SHA256:           msg.Sticker.SHA256,
+			}
+		}
+
+	case models.MessageTypeLocation:
+		if msg.Location != nil {
+			return &LocationMessageEvent{
+				BaseMessageEvent: typed(EventLocation),
+				Latitude:         msg.Location.Latitude,
+				Longitude:        msg.Location.Longitude,
+				Name:             msg.Location.Name,
+				Address:          msg.Location.Address,
+			}
+		}
+
+	case models.MessageTypeContacts:
+		if len(msg.Contacts) > 0 {
+			return &ContactsMessageEvent{
+				BaseMessageEvent: typed(EventContacts),
+				Contacts:         msg.Contacts,
+			}
+		}
+
+	case models.MessageTypeInteractive:
+		if msg.Interactive == nil {
+			break
+		}
+		switch msg.Interactive.Type {
+		case "button_reply":
+			if msg.Interactive.ButtonReply != nil {
+				return &ButtonReplyEvent{
+					BaseMessageEvent: typed(EventButtonReply),
+					ButtonID:         msg.Interactive.ButtonReply.ID,
+					ButtonTitle:      msg.Interactive.ButtonReply.Title,
+				}
+			}
+		case "list_reply":
+			if msg.Interactive.ListReply != nil {
+				return &ListReplyEvent{
+					BaseMessageEvent: typed(EventListReply),
+					RowID:            msg.Interactive.ListReply.ID,
+					RowTitle:         msg.Interactive.ListReply.Title,
+					RowDescription:   msg.Interactive.ListReply.Description,
+				}
+			}
+		case "nfm_reply":
+			reply := msg.Interactive.NfmReply
+			if reply == nil {
+				break
+			}
+			if reply.Name == "address_message" {
+				event := &AddressSubmissionEvent{
+					BaseMessageEvent: typed(EventAddressSubmission),
+					Body:             reply.Body,
+				}
+				if err := json.Unmarshal([]byte(reply.ResponseJSON), &event.AddressSubmission); err != nil {
+					h.logger.Printf("Error parsing address submission: %v", err)
+					return nil
+				}
+				return event
+			}
+			event := &FlowResponseEvent{
+				BaseMessageEvent: typed(EventFlowResponse),
+				Name:             reply.Name,
+				Body:             reply.Body,
+				ResponseJSON:     reply.ResponseJSON,
+			}
+			var token struct {
+				FlowToken string `json:"flow_token"`
+			}
+			if err := json.Unmarshal([]byte(event.ResponseJSON), &token); err == nil {
+				event.FlowToken = token.FlowToken
+			}
+			return event
+		}
+
+	case models.MessageTypeReaction:
+		if msg.Reaction != nil {
+			return &ReactionMessageEvent{
+				BaseMessageEvent: typed(EventReaction),
+				ReactedMessageID: msg.Reaction.MessageID,
+				Emoji:            msg.Reaction.Emoji,
+			}
+		}
+
+	case models.MessageTypeOrder:
+		if msg.Order != nil {
+			return &OrderEvent{
+				BaseMessageEvent: typed(EventOrder),
+				CatalogID:        msg.Order.CatalogID,
+				Text:             msg.Order.Text,
+				Items:            msg.Order.ProductItems,
+			}
+		}
+
+	case models.MessageTypeSystem:
+		if msg.System != nil {
+			return &SystemMessageEvent{
+				BaseMessageEvent: typed(EventSystem),
+				Type:             msg.System.Type,
+				Body:             msg.System.Body,
+				NewWaID:          msg.System.NewWaID,
+				Identity:         msg.System.Identity,
+			}
+		}
+	}
+
+	return nil
+}
+
+// sendReceipt marks an inbound message as read or shows a typing indicator,
+// as configured with WithReceipts, and returns the context for its handler.
+// With MinTypingDuration, replies sent with that context wait until the
+// indicator has been shown long enough; the handler itself is not delayed.
+// Nothing is sent for replayed messages.
+func (h *Handler) sendReceipt(ctx context.Context, messageID string) context.Context {
+	if h.receipts == nil || h.client == nil || IsReplay(ctx) {
+		return ctx
+	}
+
+	switch {
+	case h.receipts.Typing:
+		start := time.Now()
+		if err := h.client.SendTypingIndicator(ctx, messageID); err != nil {
+			h.logger.Printf("Error sending typing indicator for %s: %v", messageID, err)
+			return ctx
+		}
+		if h.receipts.MinTypingDuration > 0 {
+			return client.WithReplyNotBefore(ctx, start.Add(h.receipts.MinTypingDuration))
+		}
+	case h.receipts.MarkRead:
+		if err := h.client.MarkMessageAsRead(ctx, messageID); err != nil {
+			h.logger.Printf("Error marking message %s as read: %v", messageID, err)
+		}
+	}
+
+	return ctx
+}
+
+// processStatus converts a message status update into its event, or
+// returns nil for statuses without an event type.
+func processStatus(status *models.MessageStatusUpdate, value *models.WebhookValue, meta EventMeta) Event {
+	event := &MessageStatusEvent{
+		MessageID:   status.ID,
+		Status:      status.Status,
+		Timestamp:   status.Timestamp,
+		RecipientID: status.RecipientID,
+		PhoneNumber: value.Metadata.DisplayPhoneNumber,
+		PhoneID:     value.Metadata.PhoneNumberID,
+	}
+
+	if status.Conversation != nil {
+		event.ConversationID = status.Conversation.ID
+		event.ConversationType = status.Conversation.Origin.Type
+	}
+
+	if status.Pricing != nil {
+		event.Billable = status.Pricing.Billable
+		event.PricingCategory = status.Pricing.Category
+	}
+
+	event.meta = meta
+	event.meta.MessageID = status.ID
+	event.meta.Sender = status.RecipientID
+	event.meta.Timestamp = status.Timestamp
+
+	switch status.Status {
+	case models.StatusSent:
+		event.meta.Type = EventMessageSent
+	case models.StatusDelivered:
+		event.meta.Type = EventMessageDelivered
+	case models.StatusRead:
+		event.meta.Type = EventMessageRead
+	case models.StatusFailed:
+		event.meta.Type = EventMessageFailed
+		event.Errors = status.Errors
+	default:
+		return nil
+	}
+
+	return event
+}
+
+// dispatch calls the handler for the event's type, then OnEvent.
+func (h *Handler) dispatch(ctx context.Context, handlers *EventHandlers, event Event) {
+	if handle := eventHandler(handlers, event); handle != nil {
+		handle(ctx)
+	}
+
+	if handlers.OnEvent != nil {
+		handlers.OnEvent(ctx, event)
+	}
+}
+
+// handled reports whether event reaches a handler for its type, OnEvent or
+// an open subscription.
+func (h *Handler) handled(handlers *EventHandlers, event Event) bool {
+	if eventHandler(handlers, event) != nil || handlers.OnEvent != nil {
+		return true
+	}
+
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	return len(h.subs) > 0
+}
+
+// eventHandler returns a call of the handler for the event's type, or nil
+// if none is set.
+func eventHandler(handlers *EventHandlers, event Event) func(ctx context.Context) {
+	switch e := event.(type) {
+	case *TextMessageEvent:
+		if handlers.OnTextMessage != nil {
+			return func(ctx context.Context) { handlers.OnTextMessage(ctx, e) }
+		}
+	case *MediaMessageEvent:
+		var handler func(context.Context, *MediaMessageEvent)
+		switch e.Meta().Type {
+		case EventImage:
+			handler = handlers.OnImageMessage
+		case EventVideo:
+			handler = handlers.OnVideoMessage
+		case EventAudio:
+			handler = handlers.OnAudioMessage
+		case EventSticker:
+			handler = handlers.OnStickerMessage
+		}
+		if handler != nil {
+			return func(ctx context.Context) { handler(ctx, e) }
+		}
+	case *DocumentMessageEvent:
+		if handlers.OnDocumentMessage != nil {
+			return func(ctx context.Context) { handlers.OnDocumentMessage(ctx, e) }
+		}
+	case *LocationMessageEvent:
+		if handlers.OnLocationReply != nil && e.IsReply() {
+			return func(ctx context.Context) { handlers.OnLocationReply(ctx, e) }
+		} else if handlers.OnLocationMessage != nil {
+			return func(ctx context.Context) { handlers.OnLocationMessage(ctx, e) }
+		}
+	case *ContactsMessageEvent:
+		if handlers.OnContactsMessage != nil {
+			return func(ctx context.Context) { handlers.OnContactsMessage(ctx, e) }
+		}
+	case *ButtonReplyEvent:
+		if handlers.OnButtonReply != nil {
+			return func(ctx context.Context) { handlers.OnButtonReply(ctx, e) }
+		}
+	case *ListReplyEvent:
+		if handlers.OnListReply != nil {
+			return func(ctx context.Context) { handlers.OnListReply(ctx, e) }
+		}
+	case *FlowResponseEvent:
+		if handlers.OnFlowResponse != nil {
+			return func(ctx context.Context) { handlers.OnFlowResponse(ctx, e) }
+		}
+	case *AddressSubmissionEvent:
+		if handlers.OnAddressSubmission != nil {
+			return func(ctx context.Context) { handlers.OnAddressSubmission(ctx, e) }
+		}
+	case *ReactionMessageEvent:
+		if handlers.OnReactionMessage != nil {
+			return func(ctx context.Context) { handlers.OnReactionMessage(ctx, e) }
+		}
+	case *OrderEvent:
+		if handlers.OnOrder != nil {
+			return func(ctx context.Context) { handlers.OnOrder(ctx, e) }
+		}
+	case *SystemMessageEvent:
+		if handlers.OnSystemMessage != nil {
+			return func(ctx context.Context) { handlers.OnSystemMessage(ctx, e) }
+		}
+	case *MessageStatusEvent:
+		var handler func(context.Context, *MessageStatusEvent)
+		switch e.Meta().Type {
+		case EventMessageSent:
+			handler = handlers.OnMessageSent
+		case EventMessageDelivered:
+			handler = handlers.OnMessageDelivered
+		case EventMessageRead:
+			handler = handlers.OnMessageRead
+		case EventMessageFailed:
+			handler = handlers.OnMessageFailed
+		}
+		if handler != nil {
+			return func(ctx context.Context) { handler(ctx, e) }
+		}
+	case *WebhookErrorEvent:
+		if handlers.OnError != nil {
+			return func(ctx context.Context) { handlers.OnError(ctx, e) }
+		}
+	}
+
+	return nil
+}
+
+// ===============================
+// Event Types
+// ===============================
+
+// BaseMessageEvent contains common fields for all message events.
+type BaseMessageEvent struct {
+	eventMeta
+	MessageID   string
+	From        string
+	ContactName string
+	Timestamp   string
+	PhoneNumber string
+	PhoneID     string
+	Context     *models.MessageContext
+}
+
+// IsReply returns true if this message is a reply to another message.
+func (e *BaseMessageEvent) IsReply() bool {
+	return e.Context != nil && e.Context.ID != ""
+}
+
+// TextMessageEvent is emitted when a text message is received.
+type TextMessageEvent struct {
+	BaseMessageEvent
+	Body string
+}
+
+// MediaMessageEvent is emitted when a media message is received.
+type MediaMessageEvent struct {
+	BaseMessageEvent
+	MediaID  string
+	MimeType string
+	SHA256   string
+	Caption  string
+}
+
+// DocumentMessageEvent is emitted when a document message is received.
+type DocumentMessageEvent struct {
+	BaseMessageEvent
+	MediaID  string
+	MimeType string
+	SHA256   string
+	Filename string
+	Caption  string
+}
+
+// LocationMessageEvent is emitted when a location message is received.
+type LocationMessageEvent struct {
+	BaseMessageEvent
+	Latitude  float64
+	Longitude float64
+	Name      string
+	Address   string
+}
+
+// ContactsMessageEvent is emitted when a contacts message is received.
+type ContactsMessageEvent struct {
+	BaseMessageEvent
+	Contacts []models.ContactContent
+}
+
+// ButtonReplyEvent is emitted when a button reply is received.
+type ButtonReplyEvent struct {
+	BaseMessageEvent
+	ButtonID    string
+	ButtonTitle string
+}
+
+// ListReplyEvent is emitted when a list reply is received.
+type ListReplyEvent struct {
+	BaseMessageEvent
+	RowID          string
+	RowTitle       string
+	RowDescription string
+}
+
+// FlowResponseEvent is emitted when a user completes a flow.
+type FlowResponseEvent struct {
+	BaseMessageEvent
+	Name         string
+	Body         string
+	ResponseJSON string // Raw response_json sent by the flow
+	FlowToken    string // Flow token from response_json
+}
+
+// Decode unmarshals the flow response into v.
+func (e *FlowResponseEvent) Decode(v interface{}) error {
+	if e.ResponseJSON == "" {
+		return fmt.Errorf("flow response is empty")
+	}
+	if err := json.Unmarshal([]byte(e.ResponseJSON), v); err != nil {
+		return fmt.Errorf("failed to decode flow response: %w", err)
+	}
+	return nil
+}
+
+// AddressSubmissionEvent is emitted when a user submits an address message.
+type AddressSubmissionEvent struct {
+	BaseMessageEvent
+	models.AddressSubmission
+	Body string
+}
+
+// ReactionMessageEvent is emitted when a reaction is received.
+type ReactionMessageEvent struct {
+	BaseMessageEvent
+	ReactedMessageID string
+	Emoji            string
+}
+
+// OrderEvent is emitted when a customer sends an order from a cart.
+type OrderEvent struct {
+	BaseMessageEvent
+	CatalogID string
+	Text      string
+	Items     []models.OrderProductItem
+}
+
+// Total returns the exact sum of quantity times item price over all items.
+// See models.IncomingOrder.Total.
+func (e *OrderEvent) Total() (models.Amount, error) {
+	order := models.IncomingOrder{ProductItems: e.Items}
+	return order.Total()
+}
+
+// SystemMessageEvent is emitted when a customer changes their number or
+// identity. From is the customer's previous wa_id.
+type SystemMessageEvent struct {
+	BaseMessageEvent
+	Type     string // models.SystemCustomerChangedNumber, models.SystemCustomerIdentityChanged
+	Body     string
+	NewWaID  string
+	Identity string
+}
+
+// MessageStatusEvent is emitted when a message status update is received.
+type MessageStatusEvent struct {
+	eventMeta
+	MessageID        string
+	Status           models.MessageStatus
+	Timestamp        string
+	RecipientID      string
+	PhoneNumber      string
+	PhoneID          string
+	ConversationID   string
+	ConversationType string
+	Billable         bool
+	PricingCategory  string
+	Errors           []models.WebhookError
+}
+
+// WebhookErrorEvent is emitted when a webhook error is received, or when
+// processing a webhook or, with the Recover middleware, an event handler
+// panics.
+type WebhookErrorEvent struct {
+	eventMeta
+	Error    models.WebhookError
+	Metadata models.WebhookMetadata
+
+	// Set for recovered panics; Source only by the Recover middleware
+	Panic  interface{} // Value passed to panic
+	Stack  []byte      // Stack trace of the panicking goroutine
+	Source Event       // Event whose handling panicked
+}
+
+// ===============================
+// Server
+// ===============================
+
+// Server wraps the webhook handler with an HTTP server.
+type Server struct {
+	handler *Handler
+	server  *http.Server
+}
+
+// NewServer creates a new webhook server.
+func NewServer(handler *Handler, addr string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/webhook", handler)
+
+	return &Server{
+		handler: handler,
+		server: &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		},
+	}
+}
+
+// Start starts the webhook server.
+func (s *Server) Start() error {
+	s.handler.logger.Printf("Starting webhook server on %s", s.server.Addr)
+	return s.server.ListenAndServe()
+}
+
+// Shutdown gracefully shuts down the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/client"
+	"github.com/yourusername/whatsapp-go/pkg/config"
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+func TestProcessPayloadRecoversPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(h *Handler)
+	}{
+		{"raw webhook handler", func(h *Handler) {
+			h.handlers.OnRawWebhook = func(ctx context.Context, payload *models.WebhookPayload) {
+				panic("raw")
+			}
+		}},
+		{"event handler", func(h *Handler) {
+			h.handlers.OnTextMessage = func(ctx context.Context, event *TextMessageEvent) {
+				panic("text")
+			}
+		}},
+		{"subscription filter", func(h *Handler) {
+			h.Events(func(e Event) bool { panic("filter") })
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported *WebhookErrorEvent
+			h := &Handler{
+				logger: &defaultLogger{},
+				handlers: &EventHandlers{
+					OnError: func(ctx context.Context, err *WebhookErrorEvent) {
+						reported = err
+					},
+				},
+			}
+			tt.setup(h)
+
+			h.processPayload(context.Background(), textPayload("wamid.1", "hi"))
+
+			if reported == nil || reported.Panic == nil || len(reported.Stack) == 0 {
+				t.Fatalf("panic not reported to OnError with its stack: %+v", reported)
+			}
+		})
+	}
+}
+
+func TestReceiptsOnlyForHandledMessages(t *testing.T) {
+	text := textPayload("wamid.1", "hi").Entry[0].Changes[0].Value.Messages[0]
+
+	tests := []struct {
+		name  string
+		setup func(h *Handler)
+		msg   models.IncomingMessage
+		want  int
+	}{
+		{"no handlers", func(h *Handler) {}, text, 0},
+		{"handler for another type", func(h *Handler) {
+			h.handlers.OnImageMessage = func(ctx context.Context, msg *MediaMessageEvent) {}
+		}, text, 0},
+		{"handler for the type", func(h *Handler) {
+			h.handlers.OnTextMessage = func(ctx context.Context, msg *TextMessageEvent) {}
+		}, text, 1},
+		{"OnEvent", func(h *Handler) {
+			h.handlers.OnEvent = func(ctx context.Context, event Event) {}
+		}, text, 1},
+		{"subscription", func(h *Handler) {
+			h.Events()
+		}, text, 1},
+		{"system message", func(h *Handler) {
+			h.handlers.OnEvent = func(ctx context.Context, event Event) {}
+		}, models.IncomingMessage{ID: "wamid.1", From: "15551234567", Type: models.MessageTypeSystem, System: &models.SystemMessage{Type: "customer_changed_number"}}, 0},
+		{"unsupported message", func(h *Handler) {
+			h.handlers.OnEvent = func(ctx context.Context, event Event) {}
+		}, models.IncomingMessage{ID: "wamid.1", From: "15551234567", Type: "unknown"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			receipts := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				receipts++
+				mu.Unlock()
+				w.Write([]byte(`{"success":true}`))
+			}))
+			defer srv.Close()
+
+			cfg := config.DefaultConfig()
+			cfg.BaseURL = srv.URL
+			cfg.PhoneNumberID = "phone"
+			cfg.AccessToken = "token"
+			waClient, err := client.New(cfg)
+			if err != nil {
+				t.Fatalf("client.New: %v", err)
+			}
+
+			h := &Handler{
+				logger:   &defaultLogger{},
+				client:   waClient,
+				handlers: &EventHandlers{},
+				receipts: &ReceiptOptions{MarkRead: true},
+			}
+			tt.setup(h)
+
+			payload := textPayload("wamid.1", "hi")
+			payload.Entry[0].Changes[0].Value.Messages[0] = tt.msg
+			h.processPayload(context.Background(), payload)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if receipts != tt.want {
+				t.Fatalf("%d receipts sent, want %d", receipts, tt.want)
+			}
+		})
+	}
+}
+
+// textPayload returns a webhook payload with one text message.
+func textPayload(id, body string) *models.WebhookPayload {
+	return &models.WebhookPayload{
+		Entry: []models.WebhookEntry{{
+			Changes: []models.WebhookChange{{
+				Field: "messages",
+				Value: models.WebhookValue{
+					Messages: []models.IncomingMessage{{
+						ID:   id,
+						From: "15551234567",
+						Type: models.MessageTypeText,
+						Text: &models.IncomingText{Body: body},
+					}},
+				},
+			}},
+		}},
+	}
+}
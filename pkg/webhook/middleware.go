@@ -0,0 +1,150 @@
+// Package webhook provides middleware wrapping the dispatch of events to
+// the event handlers.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// EventFunc handles one event.
+type EventFunc func(ctx context.Context, event Event)
+
+// Middleware wraps an EventFunc to run code around the event handlers or
+// change the context they receive.
+type Middleware func(next EventFunc) EventFunc
+
+// Use adds middleware around the dispatch of every event to the event
+// handlers, including OnEvent. Middleware runs in the order it was added:
+// the first is the outermost. Subscriptions receive events regardless of
+// the middleware.
+func (h *Handler) Use(middleware ...Middleware) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.middleware = append(h.middleware, middleware...)
+}
+
+// chain wraps final in middleware, the first being the outermost.
+func chain(final EventFunc, middleware []Middleware) EventFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		final = middleware[i](final)
+	}
+	return final
+}
+
+// ===============================
+// Built-in Middleware
+// ===============================
+
+// Recover recovers from panics in the event handlers and in middleware
+// added after it, per event. The panic is reported to OnError as a
+// WebhookErrorEvent with Panic, Stack and Source set, and the remaining
+// events of the webhook are still dispatched; without it, a panic stops
+// processing of the whole webhook. Add it first so it covers all other
+// middleware.
+func Recover() Middleware {
+	return func(next EventFunc) EventFunc {
+		return func(ctx context.Context, event Event) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+
+				meta := event.Meta()
+				report := &WebhookErrorEvent{
+					Error: models.WebhookError{
+						Title:   "Event handler panic",
+						Message: fmt.Sprintf("panic handling %s event: %v", meta.Type, r),
+					},
+					Metadata: models.WebhookMetadata{PhoneNumberID: meta.PhoneID},
+					Panic:    r,
+					Stack:    debug.Stack(),
+					Source:   event,
+				}
+				report.meta = EventMeta{
+					Type:      EventError,
+					PhoneID:   meta.PhoneID,
+					Timestamp: meta.Timestamp,
+					Payload:   meta.Payload,
+				}
+
+				// A panic while reporting, e.g. in OnError itself, is dropped
+				defer func() { _ = recover() }()
+				next(ctx, report)
+			}()
+
+			next(ctx, event)
+		}
+	}
+}
+
+// Timeout cancels the context passed to the event handlers after d. It
+// only takes effect in handlers that respect the context, such as API
+// calls made with it.
+func Timeout(d time.Duration) Middleware {
+	return func(next EventFunc) EventFunc {
+		return func(ctx context.Context, event Event) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			next(ctx, event)
+		}
+	}
+}
+
+// Logging logs every event with its handling time, and panics passing
+// through it.
+func Logging(logger Logger) Middleware {
+	return func(next EventFunc) EventFunc {
+		return func(ctx context.Context, event Event) {
+			start := time.Now()
+			panicked := true
+			defer func() {
+				meta := event.Meta()
+				if panicked {
+					logger.Printf("Panic handling %s event %s from %s after %s", meta.Type, meta.MessageID, meta.Sender, time.Since(start))
+					return
+				}
+				logger.Printf("Handled %s event %s from %s in %s", meta.Type, meta.MessageID, meta.Sender, time.Since(start))
+			}()
+
+			next(ctx, event)
+			panicked = false
+		}
+	}
+}
+
+// MetricsRecorder receives a measurement for every event, e.g. to update
+// counters and latency histograms.
+type MetricsRecorder interface {
+	ObserveEvent(eventType EventType, duration time.Duration, panicked bool)
+}
+
+// MetricsRecorderFunc adapts a function to MetricsRecorder.
+type MetricsRecorderFunc func(eventType EventType, duration time.Duration, panicked bool)
+
+// ObserveEvent implements MetricsRecorder.
+func (f MetricsRecorderFunc) ObserveEvent(eventType EventType, duration time.Duration, panicked bool) {
+	f(eventType, duration, panicked)
+}
+
+// Metrics reports the type, handling time and outcome of every event to
+// recorder. Panics are observed and passed on.
+func Metrics(recorder MetricsRecorder) Middleware {
+	return func(next EventFunc) EventFunc {
+		return func(ctx context.Context, event Event) {
+			start := time.Now()
+			panicked := true
+			defer func() {
+				recorder.ObserveEvent(event.Meta().Type, time.Since(start), panicked)
+			}()
+
+			next(ctx, event)
+			panicked = false
+		}
+	}
+}
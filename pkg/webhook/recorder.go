@@ -0,0 +1,240 @@
+// Package webhook provides recording of raw webhook requests and replay of
+// recorded webhooks through the event handlers.
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+// RecordedWebhook is a raw webhook request as it was received. Body is
+// kept as bytes, as a webhook is recorded before it is parsed and need not
+// be valid JSON.
+type RecordedWebhook struct {
+	ReceivedAt time.Time   `json:"received_at"`
+	Headers    http.Header `json:"headers"`
+	Body       []byte      `json:"body"`
+}
+
+// RecordStore persists recorded webhooks. Implementations must be safe for
+// concurrent use.
+type RecordStore interface {
+	// Append stores a webhook after the ones already stored.
+	Append(ctx context.Context, rec *RecordedWebhook) error
+	// Each calls fn for every stored webhook in the order they were
+	// appended, stopping at the first error.
+	Each(ctx context.Context, fn func(rec *RecordedWebhook) error) error
+}
+
+// WithRecorder stores every webhook with a valid signature in store before
+// it is parsed, so it can be replayed later. Failures to store are logged
+// and do not fail the webhook.
+func WithRecorder(store RecordStore) Option {
+	return func(h *Handler) {
+		h.recorder = store
+	}
+}
+
+// record stores a received webhook if a recorder is configured.
+func (h *Handler) record(ctx context.Context, r *http.Request, body []byte, receivedAt time.Time) {
+	if h.recorder == nil {
+		return
+	}
+
+	rec := &RecordedWebhook{
+		ReceivedAt: receivedAt,
+		Headers:    r.Header.Clone(),
+		Body:       body,
+	}
+	if err := h.recorder.Append(ctx, rec); err != nil {
+		h.logger.Printf("Error recording webhook: %v", err)
+	}
+}
+
+// ===============================
+// JSONL Store
+// ===============================
+
+// JSONLStore is a RecordStore that appends webhooks to a file, one JSON
+// object per line.
+type JSONLStore struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+}
+
+// NewJSONLStore opens the file at path for appending, creating it if it
+// does not exist.
+func NewJSONLStore(path string) (*JSONLStore, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open webhook record file: %w", err)
+	}
+
+	return &JSONLStore{path: path, file: file}, nil
+}
+
+// Append implements RecordStore.
+func (s *JSONLStore) Append(ctx context.Context, rec *RecordedWebhook) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook record: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write webhook record: %w", err)
+	}
+	return nil
+}
+
+// Each implements RecordStore. Lines that cannot be decoded, such as a
+// line cut short by a crash, are skipped.
+func (s *JSONLStore) Each(ctx context.Context, fn func(rec *RecordedWebhook) error) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open webhook record file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var rec RecordedWebhook
+			if json.Unmarshal(line, &rec) == nil {
+				if err := fn(&rec); err != nil {
+					return err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read webhook record file: %w", err)
+		}
+	}
+}
+
+// Close closes the file.
+func (s *JSONLStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// ===============================
+// Replay
+// ===============================
+
+// ReplayFilter selects the recorded webhooks to replay. Zero fields match
+// every webhook.
+type ReplayFilter struct {
+	// Since and Until limit webhooks by receive time: Since is inclusive,
+	// Until exclusive
+	Since time.Time
+	Until time.Time
+	// MessageIDs limits replay to the messages and status updates with
+	// these IDs; the rest of their webhooks is left out
+	MessageIDs []string
+}
+
+// replayKey is the context key marking replayed events.
+type replayKey struct{}
+
+// IsReplay reports whether ctx belongs to an event being replayed, so
+// handlers can skip work that must not be repeated.
+func IsReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
+
+// Replay feeds the recorded webhooks in store that match filter through
+// the event handlers and middleware again, in the order they were
+// recorded, and returns how many were replayed. Replayed webhooks are
+// processed synchronously and are not recorded again. Signatures are not
+// checked, as the webhooks were verified when recorded. Side effects that
+// already happened on receipt are skipped: no read receipts or typing
+// indicators are sent, OnRawWebhook is not called and the contact
+// directory is not updated. Recorded bodies that are not valid payloads
+// are logged and skipped.
+func (h *Handler) Replay(ctx context.Context, store RecordStore, filter ReplayFilter) (int, error) {
+	ids := make(map[string]bool, len(filter.MessageIDs))
+	for _, id := range filter.MessageIDs {
+		ids[id] = true
+	}
+
+	ctx = context.WithValue(ctx, replayKey{}, true)
+	replayed := 0
+	err := store.Each(ctx, func(rec *RecordedWebhook) error {
+		if !filter.Since.IsZero() && rec.ReceivedAt.Before(filter.Since) {
+			return nil
+		}
+		if !filter.Until.IsZero() && !rec.ReceivedAt.Before(filter.Until) {
+			return nil
+		}
+
+		var payload models.WebhookPayload
+		if err := json.Unmarshal(rec.Body, &payload); err != nil {
+			h.logger.Printf("Error parsing recorded webhook from %s: %v", rec.ReceivedAt, err)
+			return nil
+		}
+		if len(ids) > 0 && !selectMessages(&payload, ids) {
+			return nil
+		}
+
+		h.processPayload(ctx, &payload)
+		replayed++
+		return nil
+	})
+
+	return replayed, err
+}
+
+// selectMessages removes the messages and status updates whose ID is not
+// in ids, and errors, from payload. It reports whether any are left.
+func selectMessages(payload *models.WebhookPayload, ids map[string]bool) bool {
+	found := false
+	for i := range payload.Entry {
+		for j := range payload.Entry[i].Changes {
+			value := &payload.Entry[i].Changes[j].Value
+
+			var messages []models.IncomingMessage
+			for _, msg := range value.Messages {
+				if ids[msg.ID] {
+					messages = append(messages, msg)
+				}
+			}
+			var statuses []models.MessageStatusUpdate
+			for _, status := range value.Statuses {
+				if ids[status.ID] {
+					statuses = append(statuses, status)
+				}
+			}
+
+			value.Messages = messages
+			value.Statuses = statuses
+			value.Errors = nil
+			found = found || len(messages) > 0 || len(statuses) > 0
+		}
+	}
+	return found
+}
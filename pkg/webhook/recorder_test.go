@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/whatsapp-go/pkg/models"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	store, err := NewJSONLStore(filepath.Join(t.TempDir(), "webhooks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewJSONLStore: %v", err)
+	}
+	defer store.Close()
+
+	h := &Handler{logger: &defaultLogger{}, handlers: &EventHandlers{}, recorder: store}
+
+	body, err := json.Marshal(textPayload("wamid.1", "hi"))
+	if err != nil {
+		t.Fatalf("encoding payload: %v", err)
+	}
+	for _, b := range [][]byte{[]byte("not json"), body} {
+		h.handleWebhook(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(b)))
+	}
+
+	var recorded [][]byte
+	store.Each(context.Background(), func(rec *RecordedWebhook) error {
+		recorded = append(recorded, rec.Body)
+		return nil
+	})
+	if len(recorded) != 2 || string(recorded[0]) != "not json" || !bytes.Equal(recorded[1], body) {
+		t.Fatalf("recorded bodies = %q, want the invalid body and the payload", recorded)
+	}
+
+	// Replay through a second handler, as the first still processes the
+	// received webhook in the background
+	var texts []string
+	raw := 0
+	replayer := &Handler{logger: &defaultLogger{}, handlers: &EventHandlers{
+		OnTextMessage: func(ctx context.Context, event *TextMessageEvent) {
+			if !IsReplay(ctx) {
+				t.Error("replayed event without replay context")
+			}
+			texts = append(texts, event.Body)
+		},
+		OnRawWebhook: func(ctx context.Context, payload *models.WebhookPayload) {
+			raw++
+		},
+	}}
+
+	n, err := replayer.Replay(context.Background(), store, ReplayFilter{})
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if n != 1 || len(texts) != 1 || texts[0] != "hi" {
+		t.Fatalf("Replay = %d with texts %q, want 1 with [hi]", n, texts)
+	}
+	if raw != 0 {
+		t.Fatalf("OnRawWebhook called %d times during replay, want 0", raw)
+	}
+}